@@ -0,0 +1,92 @@
+package strex
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// AppendReverse appends the runes of s in reverse order to dst and returns
+// the extended buffer
+func AppendReverse(dst []byte, s string) []byte {
+	return runes.AppendReverse(dst, s)
+}
+
+// AppendFilter appends the runes of s that satisfy p to dst and returns the
+// extended buffer
+func AppendFilter(dst []byte, p func(rune) bool, s string) []byte {
+	for _, r := range s {
+		if p(r) {
+			dst = utf8.AppendRune(dst, r)
+		}
+	}
+	return dst
+}
+
+// AppendDistinct appends the first occurrence of each rune of s to dst and
+// returns the extended buffer
+func AppendDistinct(dst []byte, s string) []byte {
+	var seen runes.Set
+	for _, r := range s {
+		if seen.Add(r) {
+			dst = utf8.AppendRune(dst, r)
+		}
+	}
+	return dst
+}
+
+// AppendDistinctFunc is the Append version of DistinctFunc
+func AppendDistinctFunc(dst []byte, key func(rune) rune, s string) []byte {
+	var seen runes.Set
+	for _, r := range s {
+		if seen.Add(key(r)) {
+			dst = utf8.AppendRune(dst, r)
+		}
+	}
+	return dst
+}
+
+// AppendDistinctFold is the Append version of DistinctFold
+func AppendDistinctFold(dst []byte, s string) []byte {
+	return AppendDistinctFunc(dst, runes.FoldKey, s)
+}
+
+// GroupByInto appends the groups of s that GroupBy would return to dst and
+// returns the extended slice. The groups are substrings of s.
+func GroupByInto(dst []string, p func(rune, rune) bool, s string) []string {
+	for len(s) > 0 {
+		r0, n := utf8.DecodeRuneInString(s)
+		for n < len(s) {
+			r, sz := utf8.DecodeRuneInString(s[n:])
+			if !p(r0, r) {
+				break
+			}
+			n += sz
+		}
+		dst = append(dst, s[0:n])
+		s = s[n:]
+	}
+	return dst
+}
+
+// GroupInto is the Into version of Group
+func GroupInto(dst []string, s string) []string {
+	return GroupByInto(dst, func(a, b rune) bool { return a == b }, s)
+}
+
+// WriteReverse writes the runes of s in reverse order to w
+func WriteReverse(w io.Writer, s string) (int, error) {
+	return runes.WriteReverse(w, s)
+}
+
+// WriteFilter writes the runes of s that satisfy p to w
+func WriteFilter(w io.Writer, p func(rune) bool, s string) (int, error) {
+	return runes.WriteRuns(w, p, s)
+}
+
+// WriteDistinct writes the first occurrence of each rune of s to w
+func WriteDistinct(w io.Writer, s string) (int, error) {
+	var seen runes.Set
+	return runes.WriteRuns(w, seen.Add, s)
+}
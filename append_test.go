@@ -0,0 +1,63 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+var appendInputs []string = []string{"", "hello", "日本語のテキスト", "aAbBaAé€é€", "bad\xffutf8\xfe", strings.Repeat("héllo wörld ", 50), "世" + strings.Repeat("\x80", 300)}
+
+// --------------------- APPEND ------------------------
+func TestAppendMatchesAllocatingVersions(t *testing.T) {
+	for _, s := range appendInputs {
+		assert.Equal(t, string(AppendReverse([]byte("x"), s)), "x"+Reverse(s))
+		assert.Equal(t, string(AppendFilter([]byte("x"), unicode.IsLetter, s)), "x"+Filter(unicode.IsLetter, s))
+		assert.Equal(t, string(AppendDistinct([]byte("x"), s)), "x"+Distinct(s))
+		assert.Equal(t, string(AppendDistinctFold(nil, s)), DistinctFold(s))
+		assert.Equal(t, GroupInto([]string{"x"}, s), append([]string{"x"}, Group(s)...))
+	}
+}
+
+func TestAppendDistinctManyRunes(t *testing.T) {
+	var input string = "ĀāĂăĄąĆćĈĉĊċČčĎďĐđĒēĔĕĖėĘęĚěĀāĂăĄą"
+	assert.Equal(t, string(AppendDistinct(nil, input)), Distinct(input))
+}
+
+func TestAppendDoesNotAllocate(t *testing.T) {
+	var buf []byte = make([]byte, 0, 1024)
+	var groups []string = make([]string, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		AppendReverse(buf, "héllo wörld")
+		AppendFilter(buf, unicode.IsLetter, "héllo wörld")
+		AppendDistinct(buf, "héllo wörld")
+		GroupInto(groups[:0], "aabbbcccc")
+	})
+	assert.Equal(t, allocs, 0.0)
+}
+
+// --------------------- WRITE ------------------------
+func TestWriteMatchesAllocatingVersions(t *testing.T) {
+	for _, s := range appendInputs {
+		var b strings.Builder
+		n, err := WriteReverse(&b, s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, b.String(), Reverse(s))
+		assert.Equal(t, n, len(Reverse(s)))
+
+		b.Reset()
+		_, err = WriteFilter(&b, unicode.IsLetter, s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, b.String(), Filter(unicode.IsLetter, s))
+
+		b.Reset()
+		_, err = WriteFilter(&b, func(rune) bool { return true }, s)
+		assert.Equal(t, b.String(), Filter(func(rune) bool { return true }, s))
+
+		b.Reset()
+		_, err = WriteDistinct(&b, s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, b.String(), Distinct(s))
+	}
+}
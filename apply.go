@@ -0,0 +1,285 @@
+package strex
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// pipeline transforms a string by applying the operations of a strex tag in
+// order
+type pipeline func(string) string
+
+// applier cleans the strings held by a value of a particular type in place.
+// A nil applier means the type holds nothing to clean.
+type applier func(v reflect.Value, w *walk)
+
+// visit identifies a value reached through a pointer. The type is part of
+// the key because a struct and its first field share an address.
+type visit struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// walk records the pointers followed during one Apply, so that shared and
+// cyclic values are cleaned once
+type walk struct {
+	root    visit
+	visited map[visit]bool // made when the first pointer is followed
+}
+
+// enter reports whether the value k has not been cleaned yet and marks it
+func (w *walk) enter(k visit) bool {
+	if k == w.root || w.visited[k] {
+		return false
+	}
+	if w.visited == nil {
+		w.visited = make(map[visit]bool)
+	}
+	w.visited[k] = true
+	return true
+}
+
+// plan is the compiled form of a type, stored in plans
+type plan struct {
+	apply applier
+	err   error
+}
+
+// plans caches the plan of every type given to Apply
+var plans sync.Map // reflect.Type -> plan
+
+// tagOps are the operations of a strex tag that take no argument
+var tagOps = map[string]func(string) string{
+	"reverse":          Reverse,
+	"distinct":         Distinct,
+	"distinctFold":     DistinctFold,
+	"toCamel":          ToCamel,
+	"toPascal":         ToPascal,
+	"toSnake":          ToSnake,
+	"toKebab":          ToKebab,
+	"toScreamingSnake": ToScreamingSnake,
+	"toTitleWords":     ToTitleWords,
+}
+
+// tagIntOps are the operations that take a number
+var tagIntOps = map[string]func(int, string) string{
+	"take": Take,
+	"drop": Drop,
+}
+
+// tagPredOps are the operations that take the name of a predicate
+var tagPredOps = map[string]func(func(rune) bool, string) string{
+	"takeWhile": TakeWhile,
+	"dropWhile": DropWhile,
+	"filter":    Filter,
+}
+
+// Apply cleans the string fields of the struct, or other value, that ptr
+// points to in place, following the pipelines in their strex tags:
+//
+//	type User struct {
+//		Name string   `strex:"dropWhile=space,filter=print,take=64"`
+//		Tags []string `strex:"filter=letter,distinct"`
+//	}
+//
+// The operations of a pipeline are applied from left to right. They are
+// take=N and drop=N; takeWhile=P, dropWhile=P and filter=P with P one of
+// control, digit, graphic, letter, lower, mark, number, print, punct, space,
+// symbol, title or upper, which name the unicode package predicates; and
+// reverse, distinct, distinctFold, toCamel, toPascal, toSnake, toKebab,
+// toScreamingSnake and toTitleWords.
+//
+// A tag applies to a string field, or to every string in a field that is a
+// pointer, slice, array or map of strings, where only map values are
+// changed. Nested structs are walked, through pointers, slices, arrays and
+// maps as well, and their fields follow their own tags. A value reached
+// through several pointers, including ptr itself, is cleaned only once, so
+// values with cycles are fine. Unexported fields, fields tagged "-" and
+// interfaces are left alone.
+//
+// Apply returns an error naming the field if a tag has an unknown
+// operation or a bad argument, or is on a field that holds no strings.
+// Types are compiled once and the result is cached, so later calls for the
+// same type only walk the value.
+func Apply(ptr any) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return errors.New("strex: Apply needs a non-nil pointer")
+	}
+	t := v.Type().Elem()
+	p, ok := plans.Load(t)
+	if !ok {
+		a, err := compileType(t, nil, t.String(), map[reflect.Type]*applier{})
+		p, _ = plans.LoadOrStore(t, plan{a, err})
+	}
+	pl := p.(plan)
+	if pl.err == nil && pl.apply != nil {
+		pl.apply(v.Elem(), &walk{root: visit{v.Pointer(), t}})
+	}
+	return pl.err
+}
+
+// parseTag compiles the pipeline of a strex tag. where names the field for
+// error messages.
+func parseTag(tag, where string) (pipeline, error) {
+	var ops []func(string) string
+	for _, op := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(op), "=")
+		fail := func(msg string) (pipeline, error) {
+			return nil, errors.New("strex: " + where + ": " + msg)
+		}
+		if f, ok := tagOps[name]; ok {
+			if hasArg {
+				return fail(name + " takes no argument")
+			}
+			ops = append(ops, f)
+		} else if f, ok := tagIntOps[name]; ok {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 0 {
+				return fail(name + " needs a number, not " + strconv.Quote(arg))
+			}
+			ops = append(ops, func(s string) string { return f(n, s) })
+		} else if f, ok := tagPredOps[name]; ok {
+			p, ok := namedPredicates[arg]
+			if !ok {
+				return fail("unknown predicate " + strconv.Quote(arg) + " for " + name)
+			}
+			ops = append(ops, func(s string) string { return f(p, s) })
+		} else {
+			return fail("unknown operation " + strconv.Quote(name))
+		}
+	}
+	return func(s string) string {
+		for _, op := range ops {
+			s = op(s)
+		}
+		return s
+	}, nil
+}
+
+// compileType returns the applier for values of type t, applying pipe to
+// the strings it holds directly. where names the value for error messages,
+// and seen holds the appliers of the struct types being compiled, so that
+// recursive types refer to themselves instead of being compiled forever.
+func compileType(t reflect.Type, pipe pipeline, where string, seen map[reflect.Type]*applier) (applier, error) {
+	switch t.Kind() {
+	case reflect.String:
+		if pipe == nil {
+			return nil, nil
+		}
+		return func(v reflect.Value, _ *walk) {
+			v.SetString(pipe(v.String()))
+		}, nil
+
+	case reflect.Pointer:
+		elem, err := compileType(t.Elem(), pipe, where, seen)
+		if elem == nil {
+			return nil, err
+		}
+		return func(v reflect.Value, w *walk) {
+			if v.IsNil() {
+				return
+			}
+			if w.enter(visit{v.Pointer(), t.Elem()}) {
+				elem(v.Elem(), w)
+			}
+		}, nil
+
+	case reflect.Slice, reflect.Array:
+		elem, err := compileType(t.Elem(), pipe, where, seen)
+		if elem == nil {
+			return nil, err
+		}
+		return func(v reflect.Value, w *walk) {
+			for i := 0; i < v.Len(); i++ {
+				elem(v.Index(i), w)
+			}
+		}, nil
+
+	case reflect.Map:
+		elem, err := compileType(t.Elem(), pipe, where, seen)
+		if elem == nil {
+			return nil, err
+		}
+		return func(v reflect.Value, w *walk) {
+			// map values cannot be changed in place
+			e := reflect.New(t.Elem()).Elem()
+			iter := v.MapRange()
+			for iter.Next() {
+				e.Set(iter.Value())
+				elem(e, w)
+				v.SetMapIndex(iter.Key(), e)
+			}
+		}, nil
+
+	case reflect.Struct:
+		if pipe != nil {
+			break
+		}
+		return compileStruct(t, where, seen)
+	}
+	if pipe != nil {
+		return nil, errors.New("strex: " + where + ": tag on a field of type " + t.String() + ", which holds no strings")
+	}
+	return nil, nil
+}
+
+// compileStruct returns the applier for the struct type t
+func compileStruct(t reflect.Type, where string, seen map[reflect.Type]*applier) (applier, error) {
+	if a, ok := seen[t]; ok {
+		// t contains itself; call its applier once it has been compiled
+		return func(v reflect.Value, w *walk) {
+			if *a != nil {
+				(*a)(v, w)
+			}
+		}, nil
+	}
+	self := new(applier)
+	seen[t] = self
+
+	type field struct {
+		index int
+		apply applier
+	}
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("strex")
+		at := where + "." + f.Name
+		if tag == "-" {
+			continue
+		}
+		if !f.IsExported() && !(f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			if tagged {
+				return nil, errors.New("strex: " + at + ": tag on an unexported field")
+			}
+			continue
+		}
+		var pipe pipeline
+		if tagged {
+			var err error
+			if pipe, err = parseTag(tag, at); err != nil {
+				return nil, err
+			}
+		}
+		a, err := compileType(f.Type, pipe, at, seen)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			fields = append(fields, field{i, a})
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	*self = func(v reflect.Value, w *walk) {
+		for _, f := range fields {
+			f.apply(v.Field(f.index), w)
+		}
+	}
+	return *self, nil
+}
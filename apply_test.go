@@ -0,0 +1,175 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"reflect"
+	"testing"
+)
+
+type applyAddress struct {
+	Street string `strex:"dropWhile=space,take=10"`
+	City   string `strex:"toTitleWords"`
+	Note   string
+}
+
+type applyUser struct {
+	Name      string            `strex:"dropWhile=space,filter=print,take=8,distinct"`
+	Handle    *string           `strex:"toSnake"`
+	Tags      []string          `strex:"filter=letter,reverse"`
+	Codes     [2]string         `strex:"drop=1"`
+	Labels    map[string]string `strex:"take=2"`
+	Home      applyAddress
+	Work      *applyAddress
+	Previous  []applyAddress
+	ByName    map[string]applyAddress
+	Age       int
+	Skipped   string `strex:"-"`
+	Untouched string
+	private   string
+}
+
+// --------------------- APPLY ------------------------
+func TestApply(t *testing.T) {
+	handle := "userHandleName"
+	u := applyUser{
+		Name:      "  \tJo\x00hnny Smith",
+		Handle:    &handle,
+		Tags:      []string{"a-b", "c1d"},
+		Codes:     [2]string{"x1", "y2"},
+		Labels:    map[string]string{"k": "value"},
+		Home:      applyAddress{Street: "   1 Long Street Name", City: "new york", Note: "  keep  "},
+		Work:      &applyAddress{City: "san francisco"},
+		Previous:  []applyAddress{{City: "paris"}},
+		ByName:    map[string]applyAddress{"x": {Street: "  main st"}},
+		Age:       42,
+		Skipped:   "  -  ",
+		Untouched: "  as is  ",
+		private:   "  hidden  ",
+	}
+	assert.Equal(t, Apply(&u), nil)
+	assert.Equal(t, u.Name, "Johny S")
+	assert.Equal(t, handle, "user_handle_name")
+	assert.Equal(t, u.Tags, []string{"ba", "dc"})
+	assert.Equal(t, u.Codes, [2]string{"1", "2"})
+	assert.Equal(t, u.Labels, map[string]string{"k": "va"})
+	assert.Equal(t, u.Home, applyAddress{Street: "1 Long Str", City: "New York", Note: "  keep  "})
+	assert.Equal(t, u.Work.City, "San Francisco")
+	assert.Equal(t, u.Previous[0].City, "Paris")
+	assert.Equal(t, u.ByName["x"].Street, "main st")
+	assert.Equal(t, u.Skipped, "  -  ")
+	assert.Equal(t, u.Untouched, "  as is  ")
+	assert.Equal(t, u.private, "  hidden  ")
+}
+
+func TestApplyNilsAndSlicesOfPointers(t *testing.T) {
+	u := applyUser{}
+	assert.Equal(t, Apply(&u), nil)
+
+	as := []*applyAddress{{City: "rome"}, nil}
+	assert.Equal(t, Apply(&as), nil)
+	assert.Equal(t, as[0].City, "Rome")
+}
+
+type applyNode struct {
+	Label    string `strex:"take=3"`
+	Children []*applyNode
+	Parent   *applyNode
+}
+
+func TestApplyRecursiveType(t *testing.T) {
+	n := applyNode{Label: "rootnode", Children: []*applyNode{{Label: "child", Children: []*applyNode{{Label: "grandchild"}}}}}
+	assert.Equal(t, Apply(&n), nil)
+	assert.Equal(t, n.Label, "roo")
+	assert.Equal(t, n.Children[0].Label, "chi")
+	assert.Equal(t, n.Children[0].Children[0].Label, "gra")
+}
+
+func TestApplyCycle(t *testing.T) {
+	n := applyNode{Label: "rootnode", Children: []*applyNode{{Label: "child"}}}
+	n.Parent = &n
+	n.Children[0].Parent = &n
+	n.Children = append(n.Children, n.Children[0])
+	assert.Equal(t, Apply(&n), nil)
+	assert.Equal(t, n.Label, "roo")
+	assert.Equal(t, n.Children[0].Label, "chi")
+}
+
+type applyEmbedded struct {
+	applyAddress
+	Extra string `strex:"reverse"`
+}
+
+func TestApplyEmbedded(t *testing.T) {
+	e := applyEmbedded{applyAddress{City: "oslo"}, "abc"}
+	assert.Equal(t, Apply(&e), nil)
+	assert.Equal(t, e.City, "Oslo")
+	assert.Equal(t, e.Extra, "cba")
+}
+
+type applyShared struct {
+	A, B *applyEmbedded
+}
+
+func TestApplyShared(t *testing.T) {
+	e := &applyEmbedded{Extra: "abc"}
+	s := applyShared{A: e, B: e}
+	assert.Equal(t, Apply(&s), nil)
+	assert.Equal(t, e.Extra, "cba")
+}
+
+func TestApplyErrors(t *testing.T) {
+	assert.Equal(t, Apply(applyUser{}).Error(), "strex: Apply needs a non-nil pointer")
+	assert.Equal(t, Apply((*applyUser)(nil)).Error(), "strex: Apply needs a non-nil pointer")
+
+	type unknown struct {
+		Name string `strex:"take=3,trim"`
+	}
+	assert.Equal(t, Apply(&unknown{}).Error(), `strex: strex.unknown.Name: unknown operation "trim"`)
+
+	type badNumber struct {
+		Name string `strex:"take=x"`
+	}
+	assert.Equal(t, Apply(&badNumber{}).Error(), `strex: strex.badNumber.Name: take needs a number, not "x"`)
+
+	type badPredicate struct {
+		Name string `strex:"filter=printable"`
+	}
+	assert.Equal(t, Apply(&badPredicate{}).Error(), `strex: strex.badPredicate.Name: unknown predicate "printable" for filter`)
+
+	type extraArgument struct {
+		Name string `strex:"reverse=1"`
+	}
+	assert.Equal(t, Apply(&extraArgument{}).Error(), `strex: strex.extraArgument.Name: reverse takes no argument`)
+
+	type notAString struct {
+		Age int `strex:"take=1"`
+	}
+	assert.Equal(t, Apply(&notAString{}).Error(), `strex: strex.notAString.Age: tag on a field of type int, which holds no strings`)
+
+	type nested struct {
+		Inner struct {
+			Name string `strex:"bogus"`
+		}
+	}
+	assert.Equal(t, Apply(&nested{}).Error(), `strex: strex.nested.Inner.Name: unknown operation "bogus"`)
+
+	type unexported struct {
+		name string `strex:"take=1"`
+	}
+	assert.Equal(t, Apply(&unexported{}).Error(), `strex: strex.unexported.name: tag on an unexported field`)
+}
+
+func TestApplyCachesPlans(t *testing.T) {
+	type cached struct {
+		Name string `strex:"take=2"`
+	}
+	c := cached{"abc"}
+	assert.Equal(t, Apply(&c), nil)
+	_, ok := plans.Load(reflect.TypeOf(c))
+	assert.T(t, ok)
+
+	c.Name = "xyz"
+	allocs := testing.AllocsPerRun(100, func() { Apply(&c) })
+	assert.Equal(t, c.Name, "xy")
+	assert.T(t, allocs <= 1, allocs)
+}
@@ -1,6 +1,11 @@
 package strex
 
-import "testing"
+import (
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
 
 var inputStr string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
@@ -101,3 +106,114 @@ func BenchmarkTakeWhile(b *testing.B) {
 		TakeWhile(isLower, inputStr)
 	}
 }
+
+func BenchmarkTextDrop(b *testing.B) {
+	var text *Text = NewText(inputStr)
+	for i := 0; i < b.N; i++ {
+		text.Drop(12)
+	}
+}
+
+func BenchmarkAppendReverse(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, len(inputStr))
+	for i := 0; i < b.N; i++ {
+		buf = AppendReverse(buf[:0], inputStr)
+	}
+}
+
+func BenchmarkAppendFilter(b *testing.B) {
+	var isLower func(rune) bool = func(r rune) bool { return r >= 97 && r <= 122 }
+	b.ReportAllocs()
+	buf := make([]byte, 0, len(inputStr))
+	for i := 0; i < b.N; i++ {
+		buf = AppendFilter(buf[:0], isLower, inputStr)
+	}
+}
+
+func BenchmarkAppendDistinct(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, len(inputStr))
+	for i := 0; i < b.N; i++ {
+		buf = AppendDistinct(buf[:0], inputStr)
+	}
+}
+
+func BenchmarkGroupByInto(b *testing.B) {
+	var sameCase func(rune, rune) bool = func(a, b rune) bool { return (a >= 97) == (b >= 97) }
+	b.ReportAllocs()
+	groups := make([]string, 0, 8)
+	for i := 0; i < b.N; i++ {
+		groups = GroupByInto(groups[:0], sameCase, inputStr)
+	}
+}
+
+func BenchmarkWriteFilter(b *testing.B) {
+	var isLower func(rune) bool = func(r rune) bool { return r >= 97 && r <= 122 }
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		WriteFilter(io.Discard, isLower, inputStr)
+	}
+}
+
+var corpora = []struct {
+	name string
+	text string
+}{
+	{"ASCII", strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)},
+	{"Latin1", strings.Repeat("Le cœur déçu mais l'âme plutôt naïve, Louÿs rêva de crapaüter. ", 20)},
+	{"CJK", strings.Repeat("敏捷的棕色狐狸跳过了懒狗。日本語のテキストです。", 20)},
+	{"Emoji", strings.Repeat("🦊🐶🏃‍♂️💨🌕✨🎉👍🏽", 20)},
+}
+
+func BenchmarkCorpusTake(b *testing.B) {
+	for _, c := range corpora {
+		n := utf8.RuneCountInString(c.text) / 2
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Take(n, c.text)
+			}
+		})
+	}
+}
+
+func BenchmarkCorpusDrop(b *testing.B) {
+	for _, c := range corpora {
+		n := utf8.RuneCountInString(c.text) / 2
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Drop(n, c.text)
+			}
+		})
+	}
+}
+
+func BenchmarkCorpusInit(b *testing.B) {
+	for _, c := range corpora {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Init(c.text)
+			}
+		})
+	}
+}
+
+func BenchmarkCorpusLast(b *testing.B) {
+	for _, c := range corpora {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Last(c.text)
+			}
+		})
+	}
+}
+
+func BenchmarkCorpusReverse(b *testing.B) {
+	for _, c := range corpora {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Reverse(c.text)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Package bytex provides []byte counterparts of the functions in strex, in the
+way the bytes package mirrors the strings package, so that data held in
+network or file buffers can be processed without converting it to a string
+and back.
+
+Wherever the strex function returns a substring of its argument, the bytex
+function returns a subslice of its argument that shares its memory.
+Functions that build new text return a newly allocated slice. Invalid UTF-8
+is treated exactly as strex treats it.
+
+The types of strex, such as Index, Matcher, Rope and Text, and the diff
+functions, which work with Edit values, have no counterparts here.
+*/
+package bytex
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// runeOffset returns the byte offset of the n-th rune of b, or -1 if b has
+// fewer than n runes
+func runeOffset(n int, b []byte) int {
+	i := 0
+	for ; n > 0 && i < len(b); n-- {
+		if b[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		_, sz := utf8.DecodeRune(b[i:])
+		i += sz
+	}
+	if n > 0 {
+		return -1
+	}
+	return i
+}
+
+// Head is like strex.Head. It panics if b is empty.
+func Head(b []byte) rune {
+	if len(b) == 0 {
+		panic("empty list")
+	}
+	r, _ := utf8.DecodeRune(b)
+	return r
+}
+
+// Tail is like strex.Tail. It panics if b is empty.
+func Tail(b []byte) []byte {
+	if len(b) == 0 {
+		panic("empty list")
+	}
+	_, sz := utf8.DecodeRune(b)
+	return b[sz:]
+}
+
+// Take is like strex.Take
+func Take(n int, b []byte) []byte {
+	if n <= 0 {
+		return b[:0]
+	}
+	if i := runeOffset(n, b); i >= 0 {
+		return b[:i]
+	}
+	return b
+}
+
+// Drop is like strex.Drop
+func Drop(n int, b []byte) []byte {
+	if n <= 0 {
+		return b
+	}
+	if i := runeOffset(n, b); i >= 0 {
+		return b[i:]
+	}
+	return b[len(b):]
+}
+
+// TakeWhile is like strex.TakeWhile
+func TakeWhile(p func(rune) bool, b []byte) []byte {
+	for i := 0; i < len(b); {
+		r, sz := utf8.DecodeRune(b[i:])
+		if !p(r) {
+			return b[:i]
+		}
+		i += sz
+	}
+	return b
+}
+
+// DropWhile is like strex.DropWhile
+func DropWhile(p func(rune) bool, b []byte) []byte {
+	return b[len(TakeWhile(p, b)):]
+}
+
+// Span is like strex.Span
+func Span(p func(rune) bool, b []byte) ([]byte, []byte) {
+	t := TakeWhile(p, b)
+	return t, b[len(t):]
+}
+
+// Reverse is like strex.Reverse
+func Reverse(b []byte) []byte {
+	return AppendReverse(make([]byte, 0, len(b)), b)
+}
+
+// Filter is like strex.Filter
+func Filter(p func(rune) bool, b []byte) []byte {
+	return bytes.Map(func(r rune) rune {
+		if p(r) {
+			return r
+		}
+		return -1
+	}, b)
+}
+
+// Group is like strex.Group
+func Group(b []byte) [][]byte {
+	return GroupInto([][]byte{}, b)
+}
+
+// GroupBy is like strex.GroupBy
+func GroupBy(p func(rune, rune) bool, b []byte) [][]byte {
+	return GroupByInto([][]byte{}, p, b)
+}
+
+// Distinct is like strex.Distinct
+func Distinct(b []byte) []byte {
+	var seen runes.Set
+	return bytes.Map(func(r rune) rune {
+		if seen.Add(r) {
+			return r
+		}
+		return -1
+	}, b)
+}
+
+// Last is like strex.Last. It panics if b is empty.
+func Last(b []byte) rune {
+	if len(b) == 0 {
+		panic("empty list")
+	}
+	if c := b[len(b)-1]; c < utf8.RuneSelf {
+		return rune(c)
+	}
+	r, _ := utf8.DecodeLastRune(b)
+	return r
+}
+
+// Init is like strex.Init. It panics if b is empty.
+func Init(b []byte) []byte {
+	if len(b) == 0 {
+		panic("empty list")
+	}
+	_, sz := utf8.DecodeLastRune(b)
+	return b[:len(b)-sz]
+}
+
+// Len is like strex.Len
+func Len(b []byte) int {
+	return utf8.RuneCount(b)
+}
+
+// IsEmpty is like strex.IsEmpty
+func IsEmpty(b []byte) bool {
+	return len(b) == 0
+}
+
+// All is like strex.All
+func All(p func(rune) bool, b []byte) bool {
+	return len(TakeWhile(p, b)) == len(b)
+}
+
+// Any is like strex.Any
+func Any(p func(rune) bool, b []byte) bool {
+	return !All(func(r rune) bool { return !p(r) }, b)
+}
+
+// Count is like strex.Count
+func Count(p func(rune) bool, b []byte) int {
+	n := 0
+	for i := 0; i < len(b); {
+		r, sz := utf8.DecodeRune(b[i:])
+		if p(r) {
+			n++
+		}
+		i += sz
+	}
+	return n
+}
+
+// AppendReverse is like strex.AppendReverse
+func AppendReverse(dst, b []byte) []byte {
+	return runes.AppendReverse(dst, b)
+}
+
+// AppendFilter is like strex.AppendFilter
+func AppendFilter(dst []byte, p func(rune) bool, b []byte) []byte {
+	for i := 0; i < len(b); {
+		r, sz := utf8.DecodeRune(b[i:])
+		if p(r) {
+			dst = utf8.AppendRune(dst, r)
+		}
+		i += sz
+	}
+	return dst
+}
+
+// AppendDistinct is like strex.AppendDistinct
+func AppendDistinct(dst, b []byte) []byte {
+	var seen runes.Set
+	return AppendFilter(dst, seen.Add, b)
+}
+
+// GroupByInto is like strex.GroupByInto. The groups are subslices of b.
+func GroupByInto(dst [][]byte, p func(rune, rune) bool, b []byte) [][]byte {
+	for len(b) > 0 {
+		r0, n := utf8.DecodeRune(b)
+		for n < len(b) {
+			r, sz := utf8.DecodeRune(b[n:])
+			if !p(r0, r) {
+				break
+			}
+			n += sz
+		}
+		dst = append(dst, b[:n])
+		b = b[n:]
+	}
+	return dst
+}
+
+// GroupInto is like strex.GroupInto
+func GroupInto(dst [][]byte, b []byte) [][]byte {
+	return GroupByInto(dst, func(a, b rune) bool { return a == b }, b)
+}
+
+// WriteReverse is like strex.WriteReverse
+func WriteReverse(w io.Writer, b []byte) (int, error) {
+	return runes.WriteReverse(w, b)
+}
+
+// WriteFilter is like strex.WriteFilter
+func WriteFilter(w io.Writer, p func(rune) bool, b []byte) (int, error) {
+	return runes.WriteRuns(w, p, b)
+}
+
+// WriteDistinct is like strex.WriteDistinct
+func WriteDistinct(w io.Writer, b []byte) (int, error) {
+	var seen runes.Set
+	return runes.WriteRuns(w, seen.Add, b)
+}
@@ -0,0 +1,189 @@
+package bytex
+
+import (
+	"bytes"
+	"github.com/bmizerany/assert"
+	"github.com/djhworld/strex"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+var inputs []string = []string{"", "a", "hello", "héllo wörld", "日本語のテキスト", "aAbBaAé€é€", "bad\xffutf8\xfe", "\xe4\xb8", "parseHTTPServer2_id", strings.Repeat("héllo wörld ", 30)}
+
+// within reports whether sub shares the memory of b
+func within(b, sub []byte) bool {
+	if len(sub) == 0 {
+		return true
+	}
+	for i := range b {
+		if &b[i] == &sub[0] {
+			return i+len(sub) <= len(b)
+		}
+	}
+	return false
+}
+
+func strsOf(bs [][]byte) []string {
+	ss := []string{}
+	for _, b := range bs {
+		ss = append(ss, string(b))
+	}
+	return ss
+}
+
+// --------------------- CORE ------------------------
+func TestMatchesStrex(t *testing.T) {
+	isL := unicode.IsLetter
+	for _, s := range inputs {
+		b := []byte(s)
+		for n := -1; n <= len(s)+1; n++ {
+			assert.Equal(t, string(Take(n, b)), strex.Take(n, s))
+			assert.Equal(t, string(Drop(n, b)), strex.Drop(n, s))
+			assert.T(t, within(b, Take(n, b)) && within(b, Drop(n, b)))
+		}
+		assert.Equal(t, string(TakeWhile(isL, b)), strex.TakeWhile(isL, s))
+		assert.Equal(t, string(DropWhile(isL, b)), strex.DropWhile(isL, s))
+		x, y := Span(isL, b)
+		sx, sy := strex.Span(isL, s)
+		assert.Equal(t, string(x)+"|"+string(y), sx+"|"+sy)
+		assert.Equal(t, string(Reverse(b)), strex.Reverse(s))
+		assert.Equal(t, string(Filter(isL, b)), strex.Filter(isL, s))
+		assert.Equal(t, strsOf(Group(b)), strex.Group(s))
+		assert.Equal(t, string(Distinct(b)), strex.Distinct(s))
+		assert.Equal(t, Len(b), strex.Len(s))
+		assert.Equal(t, IsEmpty(b), strex.IsEmpty(s))
+		assert.Equal(t, All(isL, b), strex.All(isL, s))
+		assert.Equal(t, Any(unicode.IsUpper, b), strex.Any(unicode.IsUpper, s))
+		assert.Equal(t, Count(isL, b), strex.Count(isL, s))
+		if s != "" {
+			assert.Equal(t, Head(b), strex.Head(s))
+			assert.Equal(t, Last(b), strex.Last(s))
+			assert.Equal(t, string(Tail(b)), strex.Tail(s))
+			assert.Equal(t, string(Init(b)), strex.Init(s))
+		}
+
+		assert.Equal(t, string(DistinctFold(b)), strex.DistinctFold(s))
+		assert.Equal(t, string(DistinctFoldSpecial(unicode.TurkishCase, b)), strex.DistinctFoldSpecial(unicode.TurkishCase, s))
+		assert.Equal(t, strsOf(GroupFold(b)), strex.GroupFold(s))
+		assert.Equal(t, strsOf(GroupFoldSpecial(unicode.TurkishCase, b)), strex.GroupFoldSpecial(unicode.TurkishCase, s))
+		for _, g := range GroupFold(b) {
+			assert.T(t, within(b, g))
+		}
+	}
+}
+
+func TestPanicsOnEmpty(t *testing.T) {
+	for _, f := range []func(){
+		func() { Head(nil) },
+		func() { Tail(nil) },
+		func() { Last(nil) },
+		func() { Init(nil) },
+	} {
+		func() {
+			defer func() {
+				assert.Equal(t, recover(), "empty list")
+			}()
+			f()
+		}()
+	}
+}
+
+// --------------------- APPEND ------------------------
+func TestAppendAndWrite(t *testing.T) {
+	for _, s := range inputs {
+		b := []byte(s)
+		assert.Equal(t, string(AppendReverse([]byte("x"), b)), "x"+strex.Reverse(s))
+		assert.Equal(t, string(AppendFilter([]byte("x"), unicode.IsLetter, b)), "x"+strex.Filter(unicode.IsLetter, s))
+		assert.Equal(t, string(AppendDistinct([]byte("x"), b)), "x"+strex.Distinct(s))
+		assert.Equal(t, string(AppendDistinctFold(nil, b)), strex.DistinctFold(s))
+		assert.Equal(t, strsOf(GroupInto([][]byte{[]byte("x")}, b)), append([]string{"x"}, strex.Group(s)...))
+
+		var w bytes.Buffer
+		n, err := WriteReverse(&w, b)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, w.String(), strex.Reverse(s))
+		assert.Equal(t, n, len(s))
+
+		w.Reset()
+		_, err = WriteFilter(&w, unicode.IsLetter, b)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, w.String(), strex.Filter(unicode.IsLetter, s))
+
+		w.Reset()
+		_, err = WriteDistinct(&w, b)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, w.String(), strex.Distinct(s))
+	}
+}
+
+// --------------------- PREFIX ------------------------
+func TestCommonPrefixAndSuffix(t *testing.T) {
+	sets := [][]string{
+		{},
+		{"interstellar", "internet", "interval"},
+		{"Straße", "STRASSE"},
+		{"Kelvin", "kelvin"},
+		{"héllo", "hélp"},
+		{"flower", "tower"},
+	}
+	for _, ss := range sets {
+		bs := bytesOf(ss)
+		assert.Equal(t, string(CommonPrefix(bs)), strex.CommonPrefix(ss))
+		assert.Equal(t, string(CommonSuffix(bs)), strex.CommonSuffix(ss))
+		assert.Equal(t, string(CommonPrefixFold(bs)), strex.CommonPrefixFold(ss))
+		assert.Equal(t, string(CommonSuffixFold(bs)), strex.CommonSuffixFold(ss))
+		assert.Equal(t, strsOf(StripCommonPrefix(bs)), strex.StripCommonPrefix(ss))
+		if len(bs) > 0 {
+			assert.T(t, within(bs[0], CommonPrefix(bs)))
+			assert.T(t, within(bs[0], CommonSuffixFold(bs)))
+		}
+	}
+}
+
+// --------------------- TEXT ------------------------
+func TestTextFunctions(t *testing.T) {
+	for _, s := range inputs {
+		b := []byte(s)
+		assert.Equal(t, strsOf(SplitIdentifier(b)), strex.SplitIdentifier(s))
+		for _, w := range SplitIdentifier(b) {
+			assert.T(t, within(b, w))
+		}
+		assert.Equal(t, string(ToCamel(b)), strex.ToCamel(s))
+		assert.Equal(t, string(ToPascal(b)), strex.ToPascal(s))
+		assert.Equal(t, string(ToSnake(b)), strex.ToSnake(s))
+		assert.Equal(t, string(ToKebab(b)), strex.ToKebab(s))
+		assert.Equal(t, string(ToScreamingSnake(b)), strex.ToScreamingSnake(s))
+		assert.Equal(t, string(ToTitleWords(b)), strex.ToTitleWords(s))
+		assert.Equal(t, string(PadLeft(b, 20, []byte("·"))), strex.PadLeft(s, 20, "·"))
+		assert.Equal(t, string(PadRight(b, 20, nil)), strex.PadRight(s, 20, ""))
+		assert.Equal(t, string(Center(b, 20, []byte("-="))), strex.Center(s, 20, "-="))
+		assert.Equal(t, string(FitExact(b, 8, []byte("…"))), strex.FitExact(s, 8, "…"))
+		assert.Equal(t, strsOf(Wrap(b, 10)), strex.Wrap(s, 10))
+		assert.Equal(t, string(Fill(b, 10)), strex.Fill(s, 10))
+		opts := strex.WrapOptions{Algorithm: strex.WrapMinRaggedness, Indent: "> "}
+		assert.Equal(t, strsOf(WrapWith(b, 12, opts)), strex.WrapWith(s, 12, opts))
+		assert.Equal(t, string(FillWith(b, 12, opts)), strex.FillWith(s, 12, opts))
+	}
+	assert.Equal(t, string(Justify(bytesOf([]string{"a", "bc", "d"}), 9)), strex.Justify([]string{"a", "bc", "d"}, 9))
+}
+
+func TestNatural(t *testing.T) {
+	pairs := [][2]string{{"file2", "file10"}, {"File2", "file10"}, {"a", "a"}, {"x01", "x1"}}
+	for _, p := range pairs {
+		a, b := []byte(p[0]), []byte(p[1])
+		assert.Equal(t, NaturalCompare(a, b), strex.NaturalCompare(p[0], p[1]))
+		assert.Equal(t, NaturalLess(a, b), strex.NaturalLess(p[0], p[1]))
+		assert.Equal(t, NaturalCompareFold(a, b), strex.NaturalCompareFold(p[0], p[1]))
+		assert.Equal(t, NaturalLessFold(a, b), strex.NaturalLessFold(p[0], p[1]))
+	}
+}
+
+func TestSubstrings(t *testing.T) {
+	a, b := []byte("the quick brown fox"), []byte("a quick brown dog")
+	l := LongestCommonSubstring(a, b)
+	assert.Equal(t, string(l), " quick brown ")
+	assert.T(t, within(a, l))
+	assert.Equal(t, string(LongestCommonSubstring(a, nil)), "")
+	assert.Equal(t, string(LCS([]byte("ABCBDAB"), []byte("BDCABA"))), strex.LCS("ABCBDAB", "BDCABA"))
+}
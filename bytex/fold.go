@@ -0,0 +1,44 @@
+package bytex
+
+import (
+	"unicode"
+
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// DistinctFunc is like strex.DistinctFunc
+func DistinctFunc(key func(rune) rune, b []byte) []byte {
+	return AppendDistinctFunc(make([]byte, 0, len(b)), key, b)
+}
+
+// DistinctFold is like strex.DistinctFold
+func DistinctFold(b []byte) []byte {
+	return DistinctFunc(runes.FoldKey, b)
+}
+
+// DistinctFoldSpecial is like strex.DistinctFoldSpecial
+func DistinctFoldSpecial(c unicode.SpecialCase, b []byte) []byte {
+	return DistinctFunc(runes.SpecialFoldKey(c), b)
+}
+
+// AppendDistinctFunc is like strex.AppendDistinctFunc
+func AppendDistinctFunc(dst []byte, key func(rune) rune, b []byte) []byte {
+	var seen runes.Set
+	return AppendFilter(dst, func(r rune) bool { return seen.Add(key(r)) }, b)
+}
+
+// AppendDistinctFold is like strex.AppendDistinctFold
+func AppendDistinctFold(dst, b []byte) []byte {
+	return AppendDistinctFunc(dst, runes.FoldKey, b)
+}
+
+// GroupFold is like strex.GroupFold
+func GroupFold(b []byte) [][]byte {
+	return GroupBy(func(x, y rune) bool { return runes.FoldKey(x) == runes.FoldKey(y) }, b)
+}
+
+// GroupFoldSpecial is like strex.GroupFoldSpecial
+func GroupFoldSpecial(c unicode.SpecialCase, b []byte) [][]byte {
+	key := runes.SpecialFoldKey(c)
+	return GroupBy(func(x, y rune) bool { return key(x) == key(y) }, b)
+}
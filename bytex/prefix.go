@@ -0,0 +1,40 @@
+package bytex
+
+import (
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// CommonPrefix is like strex.CommonPrefix. The result is a subslice of
+// bs[0].
+func CommonPrefix(bs [][]byte) []byte {
+	return runes.CommonPrefix(runes.Equal, bs)
+}
+
+// CommonSuffix is like strex.CommonSuffix. The result is a subslice of
+// bs[0].
+func CommonSuffix(bs [][]byte) []byte {
+	return runes.CommonSuffix(runes.Equal, bs)
+}
+
+// CommonPrefixFold is like strex.CommonPrefixFold. The result is a subslice
+// of bs[0].
+func CommonPrefixFold(bs [][]byte) []byte {
+	return runes.CommonPrefix(runes.EqualFold, bs)
+}
+
+// CommonSuffixFold is like strex.CommonSuffixFold. The result is a subslice
+// of bs[0].
+func CommonSuffixFold(bs [][]byte) []byte {
+	return runes.CommonSuffix(runes.EqualFold, bs)
+}
+
+// StripCommonPrefix is like strex.StripCommonPrefix. The results are
+// subslices of the elements of bs.
+func StripCommonPrefix(bs [][]byte) [][]byte {
+	n := len(CommonPrefix(bs))
+	t := make([][]byte, len(bs))
+	for i, b := range bs {
+		t[i] = b[n:]
+	}
+	return t
+}
@@ -0,0 +1,149 @@
+package bytex
+
+import (
+	"bytes"
+
+	"github.com/djhworld/strex"
+)
+
+// The functions in this file build new text or compare whole inputs, so they
+// convert to strings and use the strex implementation.
+
+// strs converts each element of bs to a string
+func strs(bs [][]byte) []string {
+	ss := make([]string, len(bs))
+	for i, b := range bs {
+		ss[i] = string(b)
+	}
+	return ss
+}
+
+// bytesOf converts each element of ss to a []byte
+func bytesOf(ss []string) [][]byte {
+	bs := make([][]byte, len(ss))
+	for i, s := range ss {
+		bs[i] = []byte(s)
+	}
+	return bs
+}
+
+// NaturalCompare is like strex.NaturalCompare
+func NaturalCompare(a, b []byte) int {
+	return strex.NaturalCompare(string(a), string(b))
+}
+
+// NaturalLess is like strex.NaturalLess
+func NaturalLess(a, b []byte) bool {
+	return strex.NaturalLess(string(a), string(b))
+}
+
+// NaturalCompareFold is like strex.NaturalCompareFold
+func NaturalCompareFold(a, b []byte) int {
+	return strex.NaturalCompareFold(string(a), string(b))
+}
+
+// NaturalLessFold is like strex.NaturalLessFold
+func NaturalLessFold(a, b []byte) bool {
+	return strex.NaturalLessFold(string(a), string(b))
+}
+
+// SplitIdentifier is like strex.SplitIdentifier. The words are subslices of
+// b.
+func SplitIdentifier(b []byte) [][]byte {
+	words := [][]byte{}
+	off := 0
+	for _, w := range strex.SplitIdentifier(string(b)) {
+		off += bytes.Index(b[off:], []byte(w))
+		words = append(words, b[off:off+len(w)])
+		off += len(w)
+	}
+	return words
+}
+
+// ToCamel is like strex.ToCamel
+func ToCamel(b []byte) []byte {
+	return []byte(strex.ToCamel(string(b)))
+}
+
+// ToPascal is like strex.ToPascal
+func ToPascal(b []byte) []byte {
+	return []byte(strex.ToPascal(string(b)))
+}
+
+// ToSnake is like strex.ToSnake
+func ToSnake(b []byte) []byte {
+	return []byte(strex.ToSnake(string(b)))
+}
+
+// ToKebab is like strex.ToKebab
+func ToKebab(b []byte) []byte {
+	return []byte(strex.ToKebab(string(b)))
+}
+
+// ToScreamingSnake is like strex.ToScreamingSnake
+func ToScreamingSnake(b []byte) []byte {
+	return []byte(strex.ToScreamingSnake(string(b)))
+}
+
+// ToTitleWords is like strex.ToTitleWords
+func ToTitleWords(b []byte) []byte {
+	return []byte(strex.ToTitleWords(string(b)))
+}
+
+// PadLeft is like strex.PadLeft
+func PadLeft(b []byte, n int, fill []byte) []byte {
+	return []byte(strex.PadLeft(string(b), n, string(fill)))
+}
+
+// PadRight is like strex.PadRight
+func PadRight(b []byte, n int, fill []byte) []byte {
+	return []byte(strex.PadRight(string(b), n, string(fill)))
+}
+
+// Center is like strex.Center
+func Center(b []byte, n int, fill []byte) []byte {
+	return []byte(strex.Center(string(b), n, string(fill)))
+}
+
+// Justify is like strex.Justify
+func Justify(words [][]byte, width int) []byte {
+	return []byte(strex.Justify(strs(words), width))
+}
+
+// FitExact is like strex.FitExact
+func FitExact(b []byte, n int, ellipsis []byte) []byte {
+	return []byte(strex.FitExact(string(b), n, string(ellipsis)))
+}
+
+// Wrap is like strex.Wrap
+func Wrap(b []byte, width int) [][]byte {
+	return bytesOf(strex.Wrap(string(b), width))
+}
+
+// Fill is like strex.Fill
+func Fill(b []byte, width int) []byte {
+	return []byte(strex.Fill(string(b), width))
+}
+
+// WrapWith is like strex.WrapWith
+func WrapWith(b []byte, width int, opts strex.WrapOptions) [][]byte {
+	return bytesOf(strex.WrapWith(string(b), width, opts))
+}
+
+// FillWith is like strex.FillWith
+func FillWith(b []byte, width int, opts strex.WrapOptions) []byte {
+	return []byte(strex.FillWith(string(b), width, opts))
+}
+
+// LCS is like strex.LCS
+func LCS(a, b []byte) []byte {
+	return []byte(strex.LCS(string(a), string(b)))
+}
+
+// LongestCommonSubstring is like strex.LongestCommonSubstring. The result is
+// a subslice of a.
+func LongestCommonSubstring(a, b []byte) []byte {
+	t := strex.LongestCommonSubstring(string(a), string(b))
+	i := bytes.Index(a, []byte(t))
+	return a[i : i+len(t)]
+}
@@ -0,0 +1,135 @@
+package strex
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// EOF is returned by the Cursor methods that read a rune when there is no
+// rune left to read
+const EOF rune = -1
+
+// Pos is a position within the string of a Cursor
+type Pos struct {
+	Offset int // byte offset, starting at 0
+	Rune   int // rune offset, starting at 0
+	Line   int // line number, starting at 1
+	Column int // column number in runes, starting at 1
+}
+
+// String returns the position in line:column form
+func (p Pos) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// Cursor walks over a string one rune at a time while keeping track of its
+// position, so that lexers written with it can report where they failed.
+type Cursor struct {
+	s   string
+	pos Pos
+}
+
+// NewCursor returns a Cursor positioned at the first rune of s
+func NewCursor(s string) *Cursor {
+	return &Cursor{s: s, pos: Pos{Line: 1, Column: 1}}
+}
+
+// Pos returns the current position of c
+func (c *Cursor) Pos() Pos {
+	return c.pos
+}
+
+// Mark returns the current position of c so that it can later be restored
+// with Reset
+func (c *Cursor) Mark() Pos {
+	return c.pos
+}
+
+// Reset moves c back (or forward) to a position previously returned by Mark
+// or Pos
+func (c *Cursor) Reset(p Pos) {
+	c.pos = p
+}
+
+// Rest returns the part of the string that has not been consumed yet
+func (c *Cursor) Rest() string {
+	return c.s[c.pos.Offset:]
+}
+
+// Since returns the part of the string consumed between p and the current
+// position
+func (c *Cursor) Since(p Pos) string {
+	return c.s[p.Offset:c.pos.Offset]
+}
+
+// Done reports whether the whole string has been consumed
+func (c *Cursor) Done() bool {
+	return c.pos.Offset >= len(c.s)
+}
+
+// Peek returns the next rune without consuming it, or EOF
+func (c *Cursor) Peek() rune {
+	if c.Done() {
+		return EOF
+	}
+	r, _ := utf8.DecodeRuneInString(c.s[c.pos.Offset:])
+	return r
+}
+
+// Next consumes and returns the next rune, or returns EOF
+func (c *Cursor) Next() rune {
+	if c.Done() {
+		return EOF
+	}
+	r, sz := utf8.DecodeRuneInString(c.s[c.pos.Offset:])
+	c.pos.Offset += sz
+	c.pos.Rune++
+	if r == '\n' {
+		c.pos.Line++
+		c.pos.Column = 1
+	} else {
+		c.pos.Column++
+	}
+	return r
+}
+
+// Prev moves c back by one rune and returns that rune, or returns EOF if c
+// is at the start of the string
+func (c *Cursor) Prev() rune {
+	if c.pos.Offset == 0 {
+		return EOF
+	}
+	r, sz := utf8.DecodeLastRuneInString(c.s[:c.pos.Offset])
+	c.pos.Offset -= sz
+	c.pos.Rune--
+	if r == '\n' {
+		c.pos.Line--
+		c.pos.Column = 1
+		for t := c.s[:c.pos.Offset]; t != ""; c.pos.Column++ {
+			p, n := utf8.DecodeLastRuneInString(t)
+			if p == '\n' {
+				break
+			}
+			t = t[:len(t)-n]
+		}
+	} else {
+		c.pos.Column--
+	}
+	return r
+}
+
+// TakeWhile consumes and returns the longest prefix of the rest of the
+// string whose runes satisfy p
+func (c *Cursor) TakeWhile(p func(rune) bool) string {
+	start := c.pos
+	c.SkipWhile(p)
+	return c.Since(start)
+}
+
+// SkipWhile consumes the longest prefix of the rest of the string whose runes
+// satisfy p
+func (c *Cursor) SkipWhile(p func(rune) bool) {
+	for r := c.Peek(); r != EOF && p(r); r = c.Peek() {
+		c.Next()
+	}
+}
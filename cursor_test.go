@@ -0,0 +1,59 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"testing"
+	"unicode"
+)
+
+// --------------------- CURSOR ------------------------
+func TestCursorNext(t *testing.T) {
+	var c *Cursor = NewCursor("añ\nb")
+	assert.Equal(t, c.Peek(), 'a')
+	assert.Equal(t, c.Next(), 'a')
+	assert.Equal(t, c.Next(), 'ñ')
+	assert.Equal(t, c.Pos(), Pos{Offset: 3, Rune: 2, Line: 1, Column: 3})
+	assert.Equal(t, c.Next(), '\n')
+	assert.Equal(t, c.Pos(), Pos{Offset: 4, Rune: 3, Line: 2, Column: 1})
+	assert.Equal(t, c.Next(), 'b')
+	assert.Equal(t, c.Next(), EOF)
+	assert.Equal(t, c.Peek(), EOF)
+	assert.Equal(t, c.Done(), true)
+}
+
+func TestCursorPrev(t *testing.T) {
+	var c *Cursor = NewCursor("añ\nb")
+	for c.Next() != EOF {
+	}
+	assert.Equal(t, c.Prev(), 'b')
+	assert.Equal(t, c.Prev(), '\n')
+	assert.Equal(t, c.Pos(), Pos{Offset: 3, Rune: 2, Line: 1, Column: 3})
+	assert.Equal(t, c.Prev(), 'ñ')
+	assert.Equal(t, c.Prev(), 'a')
+	assert.Equal(t, c.Prev(), EOF)
+	assert.Equal(t, c.Pos(), Pos{Offset: 0, Rune: 0, Line: 1, Column: 1})
+}
+
+func TestCursorTakeWhile(t *testing.T) {
+	var c *Cursor = NewCursor("count = 42")
+	assert.Equal(t, c.TakeWhile(unicode.IsLetter), "count")
+	c.SkipWhile(unicode.IsSpace)
+	assert.Equal(t, c.Next(), '=')
+	c.SkipWhile(unicode.IsSpace)
+	var p Pos = c.Pos()
+	assert.Equal(t, p.String(), "1:9")
+	assert.Equal(t, c.TakeWhile(unicode.IsDigit), "42")
+	assert.Equal(t, c.Rest(), "")
+}
+
+func TestCursorMarkReset(t *testing.T) {
+	var c *Cursor = NewCursor("abc")
+	c.Next()
+	var m Pos = c.Mark()
+	c.Next()
+	c.Next()
+	assert.Equal(t, c.Since(m), "bc")
+	c.Reset(m)
+	assert.Equal(t, c.Rest(), "bc")
+	assert.Equal(t, c.Pos().Column, 2)
+}
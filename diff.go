@@ -0,0 +1,378 @@
+package strex
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DiffOp says what an Edit does
+type DiffOp int
+
+const (
+	DiffEqual  DiffOp = iota // text present in both strings
+	DiffInsert               // text only present in the second string
+	DiffDelete               // text only present in the first string
+)
+
+// Edit is a run of runes that are equal in, inserted into or deleted from
+// the first string of a diff
+type Edit struct {
+	Op   DiffOp
+	Text string
+}
+
+// span is a run of n elements with the same operation
+type span struct {
+	op DiffOp
+	n  int
+}
+
+// appendSpan adds n elements of op to spans, extending the last span if it
+// has the same operation
+func appendSpan(spans []span, op DiffOp, n int) []span {
+	if n == 0 {
+		return spans
+	}
+	if k := len(spans); k > 0 && spans[k-1].op == op {
+		spans[k-1].n += n
+		return spans
+	}
+	return append(spans, span{op, n})
+}
+
+// myers returns a shortest edit script turning a into b, computed with the
+// O(ND) algorithm of Eugene W. Myers. Deletions come before insertions within
+// each changed region.
+func myers[T comparable](a, b []T) []span {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	spans := appendSpan(nil, DiffEqual, pre)
+	a, b = a[pre:len(a)-suf], b[pre:len(b)-suf]
+	n, m := len(a), len(b)
+
+	switch {
+	case n == 0:
+		spans = appendSpan(spans, DiffInsert, m)
+	case m == 0:
+		spans = appendSpan(spans, DiffDelete, n)
+	default:
+		spans = append(spans, myersMiddle(a, b)...)
+	}
+	return appendSpan(spans, DiffEqual, suf)
+}
+
+func myersMiddle[T comparable](a, b []T) []span {
+	n, m := len(a), len(b)
+	off := n + m + 1
+	v := make([]int, 2*off+1)
+	var trace [][]int
+	found := false
+	for d := 0; d <= n+m && !found; d++ {
+		trace = append(trace, slices.Clone(v[off-d:off+d+1]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// walk back from (n, m), collecting single steps in reverse
+	var ops []DiffOp
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		vp := trace[d]
+		k := x - y
+		var pk int
+		if k == -d || (k != d && vp[k-1+d] < vp[k+1+d]) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := vp[pk+d]
+		py := px - pk
+		for x > px && y > py {
+			ops = append(ops, DiffEqual)
+			x--
+			y--
+		}
+		if pk == k+1 {
+			ops = append(ops, DiffInsert)
+		} else {
+			ops = append(ops, DiffDelete)
+		}
+		x, y = px, py
+	}
+	for ; x > 0; x-- {
+		ops = append(ops, DiffEqual)
+	}
+
+	var spans []span
+	for i := len(ops) - 1; i >= 0; i-- {
+		spans = appendSpan(spans, ops[i], 1)
+	}
+	return normalizeSpans(spans)
+}
+
+// normalizeSpans reorders each run of changes so that the deletion comes
+// before the insertion
+func normalizeSpans(spans []span) []span {
+	var out []span
+	for i := 0; i < len(spans); {
+		if spans[i].op == DiffEqual {
+			out = appendSpan(out, DiffEqual, spans[i].n)
+			i++
+			continue
+		}
+		del, ins := 0, 0
+		for ; i < len(spans) && spans[i].op != DiffEqual; i++ {
+			if spans[i].op == DiffDelete {
+				del += spans[i].n
+			} else {
+				ins += spans[i].n
+			}
+		}
+		out = appendSpan(out, DiffDelete, del)
+		out = appendSpan(out, DiffInsert, ins)
+	}
+	return out
+}
+
+// Diff returns a minimal list of edits that turns a into b, computed at rune
+// granularity. Concatenating the Text of every DiffEqual and DiffDelete edit
+// gives a, and of every DiffEqual and DiffInsert edit gives b.
+func Diff(a, b string) []Edit {
+	ra, rb := []rune(a), []rune(b)
+	edits := []Edit{}
+	i, j := 0, 0
+	for _, sp := range myers(ra, rb) {
+		switch sp.op {
+		case DiffEqual:
+			edits = append(edits, Edit{DiffEqual, string(ra[i : i+sp.n])})
+			i += sp.n
+			j += sp.n
+		case DiffDelete:
+			edits = append(edits, Edit{DiffDelete, string(ra[i : i+sp.n])})
+			i += sp.n
+		case DiffInsert:
+			edits = append(edits, Edit{DiffInsert, string(rb[j : j+sp.n])})
+			j += sp.n
+		}
+	}
+	return edits
+}
+
+// LCS returns a longest common subsequence of a and b
+func LCS(a, b string) string {
+	var t strings.Builder
+	for _, e := range Diff(a, b) {
+		if e.Op == DiffEqual {
+			t.WriteString(e.Text)
+		}
+	}
+	return t.String()
+}
+
+// mergeEdits joins neighbouring edits with the same operation, drops empty
+// edits and puts the deletion of each changed region before its insertion
+func mergeEdits(edits []Edit) []Edit {
+	out := []Edit{}
+	for i := 0; i < len(edits); {
+		if edits[i].Op == DiffEqual {
+			if k := len(out); k > 0 && out[k-1].Op == DiffEqual {
+				out[k-1].Text += edits[i].Text
+			} else if edits[i].Text != "" {
+				out = append(out, edits[i])
+			}
+			i++
+			continue
+		}
+		var del, ins strings.Builder
+		for ; i < len(edits) && edits[i].Op != DiffEqual; i++ {
+			if edits[i].Op == DiffDelete {
+				del.WriteString(edits[i].Text)
+			} else {
+				ins.WriteString(edits[i].Text)
+			}
+		}
+		if del.Len() > 0 {
+			out = append(out, Edit{DiffDelete, del.String()})
+		}
+		if ins.Len() > 0 {
+			out = append(out, Edit{DiffInsert, ins.String()})
+		}
+	}
+	return out
+}
+
+// CleanupSemantic makes a diff easier for people to read. Small equalities
+// that are surrounded by larger changes on both sides, such as the shared
+// "e" in a diff of "mouse" and "sofas", are turned into a deletion and an
+// insertion, and the changes around them are merged.
+func CleanupSemantic(edits []Edit) []Edit {
+	edits = mergeEdits(edits)
+	for changed := true; changed; {
+		changed = false
+		for i := 1; i < len(edits)-1; i++ {
+			if edits[i].Op != DiffEqual {
+				continue
+			}
+			n := utf8.RuneCountInString(edits[i].Text)
+			if n <= changeSize(edits[:i], -1) && n <= changeSize(edits[i+1:], 1) {
+				rest := append([]Edit{{DiffDelete, edits[i].Text}, {DiffInsert, edits[i].Text}}, edits[i+1:]...)
+				edits = mergeEdits(append(edits[:i:i], rest...))
+				changed = true
+				break
+			}
+		}
+	}
+	return edits
+}
+
+// changeSize returns the larger of the number of deleted and inserted runes
+// in the run of changes at the end (dir < 0) or start (dir > 0) of edits
+func changeSize(edits []Edit, dir int) int {
+	del, ins := 0, 0
+	for k := 0; k < len(edits); k++ {
+		e := edits[k]
+		if dir < 0 {
+			e = edits[len(edits)-1-k]
+		}
+		if e.Op == DiffEqual {
+			break
+		}
+		if e.Op == DiffDelete {
+			del += utf8.RuneCountInString(e.Text)
+		} else {
+			ins += utf8.RuneCountInString(e.Text)
+		}
+	}
+	return max(del, ins)
+}
+
+// lines splits s after each newline
+func lines(s string) []string {
+	ls := strings.SplitAfter(s, "\n")
+	if ls[len(ls)-1] == "" {
+		ls = ls[:len(ls)-1]
+	}
+	return ls
+}
+
+// Unified renders edits in the unified diff format, comparing the two
+// strings line by line with context lines of unchanged text around each
+// hunk. It returns an empty string if the strings are equal.
+func Unified(edits []Edit, context int) string {
+	var a, b strings.Builder
+	for _, e := range edits {
+		if e.Op != DiffInsert {
+			a.WriteString(e.Text)
+		}
+		if e.Op != DiffDelete {
+			b.WriteString(e.Text)
+		}
+	}
+	al, bl := lines(a.String()), lines(b.String())
+
+	// one entry per line of output, with the index of the line in a or b
+	type line struct {
+		op   DiffOp
+		i, j int
+	}
+	var ls []line
+	i, j := 0, 0
+	for _, sp := range myers(al, bl) {
+		for k := 0; k < sp.n; k++ {
+			ls = append(ls, line{sp.op, i, j})
+			switch sp.op {
+			case DiffEqual:
+				i++
+				j++
+			case DiffDelete:
+				i++
+			case DiffInsert:
+				j++
+			}
+		}
+	}
+
+	var out strings.Builder
+	for h := 0; h < len(ls); {
+		for h < len(ls) && ls[h].op == DiffEqual {
+			h++
+		}
+		if h == len(ls) {
+			break
+		}
+		start := max(0, h-context)
+		end := h
+		for k := h; k < len(ls) && k <= end+2*context+1; k++ {
+			if ls[k].op != DiffEqual {
+				end = k
+			}
+		}
+		end = min(len(ls), end+context+1)
+
+		oldN, newN := 0, 0
+		for _, l := range ls[start:end] {
+			if l.op != DiffInsert {
+				oldN++
+			}
+			if l.op != DiffDelete {
+				newN++
+			}
+		}
+		out.WriteString("@@ -" + hunkRange(ls[start].i, oldN) + " +" + hunkRange(ls[start].j, newN) + " @@\n")
+		for _, l := range ls[start:end] {
+			var text string
+			switch l.op {
+			case DiffEqual:
+				out.WriteByte(' ')
+				text = al[l.i]
+			case DiffDelete:
+				out.WriteByte('-')
+				text = al[l.i]
+			case DiffInsert:
+				out.WriteByte('+')
+				text = bl[l.j]
+			}
+			out.WriteString(text)
+			if !strings.HasSuffix(text, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		h = end
+	}
+	return out.String()
+}
+
+// hunkRange formats the start and length of a hunk the way diff -u does
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return strconv.Itoa(start) + ",0"
+	}
+	if n == 1 {
+		return strconv.Itoa(start + 1)
+	}
+	return strconv.Itoa(start+1) + "," + strconv.Itoa(n)
+}
@@ -0,0 +1,129 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"math/rand"
+	"testing"
+	"unicode/utf8"
+)
+
+// lcsLength is the textbook dynamic programming solution, used as a reference
+func lcsLength(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func applyEdits(edits []Edit) (string, string) {
+	var a, b string
+	for _, e := range edits {
+		if e.Op != DiffInsert {
+			a += e.Text
+		}
+		if e.Op != DiffDelete {
+			b += e.Text
+		}
+	}
+	return a, b
+}
+
+// --------------------- DIFF ------------------------
+func TestDiff(t *testing.T) {
+	var expected []Edit = []Edit{
+		{DiffEqual, "ca"},
+		{DiffDelete, "f"},
+		{DiffInsert, "k"},
+		{DiffEqual, "é"},
+	}
+	assert.Equal(t, Diff("café", "caké"), expected)
+	assert.Equal(t, Diff("", ""), []Edit{})
+	assert.Equal(t, Diff("", "ab"), []Edit{{DiffInsert, "ab"}})
+	assert.Equal(t, Diff("ab", ""), []Edit{{DiffDelete, "ab"}})
+}
+
+func TestDiffRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var alphabet []rune = []rune("abcé世")
+	random := func() string {
+		rs := make([]rune, rnd.Intn(30))
+		for i := range rs {
+			rs[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(rs)
+	}
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		edits := Diff(a, b)
+		x, y := applyEdits(edits)
+		assert.Equal(t, x, a)
+		assert.Equal(t, y, b)
+		assert.Equal(t, utf8.RuneCountInString(LCS(a, b)), lcsLength([]rune(a), []rune(b)), a, b)
+
+		x, y = applyEdits(CleanupSemantic(edits))
+		assert.Equal(t, x, a)
+		assert.Equal(t, y, b)
+	}
+}
+
+// --------------------- LCS ------------------------
+func TestLCS(t *testing.T) {
+	assert.Equal(t, LCS("ABCBDAB", "BDCABA"), "BDAB")
+	assert.Equal(t, LCS("日本語", "日曜語"), "日語")
+	assert.Equal(t, LCS("abc", "xyz"), "")
+}
+
+// --------------------- CLEANUPSEMANTIC ------------------------
+func TestCleanupSemantic(t *testing.T) {
+	var input []Edit = []Edit{
+		{DiffDelete, "mou"},
+		{DiffInsert, "sofa"},
+		{DiffEqual, "s"},
+		{DiffDelete, "e"},
+	}
+	var expected []Edit = []Edit{
+		{DiffDelete, "mouse"},
+		{DiffInsert, "sofas"},
+	}
+	assert.Equal(t, CleanupSemantic(input), expected)
+
+	var kept []Edit = []Edit{
+		{DiffEqual, "hello "},
+		{DiffDelete, "a"},
+		{DiffInsert, "b"},
+		{DiffEqual, " world"},
+	}
+	assert.Equal(t, CleanupSemantic(kept), kept)
+}
+
+// --------------------- UNIFIED ------------------------
+func TestUnified(t *testing.T) {
+	var a string = "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	var b string = "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\neleven"
+	var expected string = "@@ -1,6 +1,6 @@\n" +
+		" 1\n 2\n-3\n+three\n 4\n 5\n 6\n" +
+		"@@ -8,3 +8,4 @@\n" +
+		" 8\n 9\n 10\n+eleven\n\\ No newline at end of file\n"
+	assert.Equal(t, Unified(Diff(a, b), 3), expected)
+	assert.Equal(t, Unified(Diff(a, a), 3), "")
+}
+
+func TestUnifiedMerge(t *testing.T) {
+	//expected output is that of diff -U1
+	//changes 2*context lines apart share a hunk
+	assert.Equal(t, Unified(Diff("x\n1\n2\ny\n", "X\n1\n2\nY\n"), 1),
+		"@@ -1,4 +1,4 @@\n-x\n+X\n 1\n 2\n-y\n+Y\n")
+	//one more line splits them
+	assert.Equal(t, Unified(Diff("x\n1\n2\n3\ny\n", "X\n1\n2\n3\nY\n"), 1),
+		"@@ -1,2 +1,2 @@\n-x\n+X\n 1\n"+
+			"@@ -4,2 +4,2 @@\n 3\n-y\n+Y\n")
+}
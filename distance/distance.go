@@ -0,0 +1,342 @@
+/*
+Package distance implements string distance and similarity measures that work
+on runes rather than bytes, so that "José" and "Jose" are one edit apart.
+
+Every function except Hamming, which needs no buffers, is also available as a
+method on Scratch, which keeps the buffers it needs between calls so that
+repeated comparisons do not allocate.
+*/
+package distance
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// ErrLengthMismatch is returned by Hamming when the strings do not have the
+// same number of runes
+var ErrLengthMismatch = errors.New("distance: strings differ in rune length")
+
+// Scratch holds reusable buffers for the distance functions. The zero value
+// is ready to use. A Scratch must not be used by several goroutines at once.
+type Scratch struct {
+	a, b   []rune
+	ints   []int
+	fa, fb []bool
+	last   map[rune]int
+}
+
+// decode returns the runes of a and b, reusing the scratch buffers
+func (s *Scratch) decode(a, b string) ([]rune, []rune) {
+	s.a = appendRunes(s.a[:0], a)
+	s.b = appendRunes(s.b[:0], b)
+	return s.a, s.b
+}
+
+func appendRunes(dst []rune, s string) []rune {
+	for _, r := range s {
+		dst = append(dst, r)
+	}
+	return dst
+}
+
+// intBuf returns a slice of n ints with unspecified contents
+func (s *Scratch) intBuf(n int) []int {
+	if cap(s.ints) < n {
+		s.ints = make([]int, n)
+	}
+	return s.ints[:n]
+}
+
+// boolBufs returns two slices of false values of length n and m
+func (s *Scratch) boolBufs(n, m int) ([]bool, []bool) {
+	if cap(s.fa) < n {
+		s.fa = make([]bool, n)
+	}
+	if cap(s.fb) < m {
+		s.fb = make([]bool, m)
+	}
+	fa, fb := s.fa[:n], s.fb[:m]
+	clear(fa)
+	clear(fb)
+	return fa, fb
+}
+
+// trim removes the common prefix and suffix of a and b, which never changes
+// the edit distance
+func trim(a, b []rune) ([]rune, []rune) {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	return a, b
+}
+
+// Levenshtein returns the minimum number of rune insertions, deletions and
+// substitutions needed to turn a into b
+func Levenshtein(a, b string) int {
+	var s Scratch
+	return s.Levenshtein(a, b)
+}
+
+// Levenshtein is the allocation-free version of Levenshtein
+func (s *Scratch) Levenshtein(a, b string) int {
+	ra, rb := trim(s.decode(a, b))
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	row := s.intBuf(len(rb) + 1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		diag := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d := min(row[j]+1, row[j-1]+1, diag+cost)
+			diag, row[j] = row[j], d
+		}
+	}
+	return row[len(rb)]
+}
+
+// LevenshteinBounded returns the Levenshtein distance between a and b if it
+// is at most k, and k+1 otherwise. It only looks at the diagonal band of
+// width 2k+1 and stops as soon as the bound is exceeded, which makes it much
+// faster than Levenshtein for small k. A negative k is treated as 0.
+func LevenshteinBounded(a, b string, k int) int {
+	var s Scratch
+	return s.LevenshteinBounded(a, b, k)
+}
+
+// LevenshteinBounded is the allocation-free version of LevenshteinBounded
+func (s *Scratch) LevenshteinBounded(a, b string, k int) int {
+	k = max(k, 0)
+	if d := utf8.RuneCountInString(a) - utf8.RuneCountInString(b); d > k || -d > k {
+		return k + 1
+	}
+	ra, rb := trim(s.decode(a, b))
+	n, m := len(ra), len(rb)
+	inf := k + 1
+	row := s.intBuf(m + 2)
+	for j := 0; j <= m+1; j++ {
+		row[j] = min(j, inf)
+	}
+	for i := 1; i <= n; i++ {
+		lo, hi := max(1, i-k), min(m, i+k)
+		diag := row[lo-1]
+		if lo == 1 {
+			row[0] = min(i, inf)
+		} else {
+			row[lo-1] = inf
+		}
+		best := row[lo-1]
+		for j := lo; j <= hi; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d := min(row[j]+1, row[j-1]+1, diag+cost, inf)
+			diag, row[j] = row[j], d
+			best = min(best, d)
+		}
+		if hi < m {
+			row[hi+1] = inf
+		}
+		if best >= inf {
+			return inf
+		}
+	}
+	return row[m]
+}
+
+// OSA returns the optimal string alignment distance between a and b: the
+// Levenshtein distance extended with transpositions of two adjacent runes,
+// where no substring may be edited more than once
+func OSA(a, b string) int {
+	var s Scratch
+	return s.OSA(a, b)
+}
+
+// OSA is the allocation-free version of OSA
+func (s *Scratch) OSA(a, b string) int {
+	ra, rb := trim(s.decode(a, b))
+	m := len(rb)
+	buf := s.intBuf(3 * (m + 1))
+	prev2, prev, cur := buf[:m+1], buf[m+1:2*(m+1)], buf[2*(m+1):]
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d := min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d = min(d, prev2[j-2]+1)
+			}
+			cur[j] = d
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[m]
+}
+
+// DamerauLevenshtein returns the Damerau-Levenshtein distance between a and
+// b: the minimum number of insertions, deletions, substitutions and
+// transpositions of adjacent runes needed to turn a into b. Unlike OSA a
+// substring may be edited after being transposed, so "CA" and "ABC" are two
+// edits apart rather than three.
+func DamerauLevenshtein(a, b string) int {
+	var s Scratch
+	return s.DamerauLevenshtein(a, b)
+}
+
+// DamerauLevenshtein is the allocation-free version of DamerauLevenshtein. It
+// only allocates when it sees more distinct runes than in earlier calls.
+func (s *Scratch) DamerauLevenshtein(a, b string) int {
+	ra, rb := trim(s.decode(a, b))
+	n, m := len(ra), len(rb)
+	if s.last == nil {
+		s.last = make(map[rune]int)
+	}
+	clear(s.last)
+	w := m + 2
+	d := s.intBuf((n + 2) * w)
+	inf := n + m
+	d[0] = inf
+	for i := 0; i <= n; i++ {
+		d[(i+1)*w] = inf
+		d[(i+1)*w+1] = i
+	}
+	for j := 0; j <= m; j++ {
+		d[j+1] = inf
+		d[w+j+1] = j
+	}
+	for i := 1; i <= n; i++ {
+		db := 0
+		for j := 1; j <= m; j++ {
+			i1 := s.last[rb[j-1]]
+			j1 := db
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+				db = j
+			}
+			d[(i+1)*w+j+1] = min(
+				d[i*w+j]+cost,
+				d[(i+1)*w+j]+1,
+				d[i*w+j+1]+1,
+				d[i1*w+j1]+(i-i1-1)+1+(j-j1-1),
+			)
+		}
+		s.last[ra[i-1]] = i
+	}
+	return d[(n+1)*w+m+1]
+}
+
+// Hamming returns the number of positions at which the runes of a and b
+// differ. It returns ErrLengthMismatch if a and b differ in rune length.
+func Hamming(a, b string) (int, error) {
+	d := 0
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			d++
+		}
+		a, b = a[na:], b[nb:]
+	}
+	if a != "" || b != "" {
+		return 0, ErrLengthMismatch
+	}
+	return d, nil
+}
+
+// Jaro returns the Jaro similarity of a and b, between 0 for no similarity
+// and 1 for equal strings
+func Jaro(a, b string) float64 {
+	var s Scratch
+	return s.Jaro(a, b)
+}
+
+// Jaro is the allocation-free version of Jaro
+func (s *Scratch) Jaro(a, b string) float64 {
+	ra, rb := s.decode(a, b)
+	return s.jaro(ra, rb)
+}
+
+func (s *Scratch) jaro(ra, rb []rune) float64 {
+	n, m := len(ra), len(rb)
+	if n == 0 && m == 0 {
+		return 1
+	}
+	if n == 0 || m == 0 {
+		return 0
+	}
+	window := max(n, m)/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	fa, fb := s.boolBufs(n, m)
+	matches := 0
+	for i := 0; i < n; i++ {
+		lo, hi := max(0, i-window), min(m-1, i+window)
+		for j := lo; j <= hi; j++ {
+			if !fb[j] && ra[i] == rb[j] {
+				fa[i], fb[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	t, j := 0, 0
+	for i := 0; i < n; i++ {
+		if !fa[i] {
+			continue
+		}
+		for !fb[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			t++
+		}
+		j++
+	}
+	mf := float64(matches)
+	return (mf/float64(n) + mf/float64(m) + (mf-float64(t)/2)/mf) / 3
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of a and b. It boosts the
+// Jaro similarity of strings that share a prefix of up to four runes by a
+// scaling factor of 0.1 per rune, provided the Jaro similarity exceeds 0.7.
+func JaroWinkler(a, b string) float64 {
+	var s Scratch
+	return s.JaroWinkler(a, b)
+}
+
+// JaroWinkler is the allocation-free version of JaroWinkler
+func (s *Scratch) JaroWinkler(a, b string) float64 {
+	ra, rb := s.decode(a, b)
+	j := s.jaro(ra, rb)
+	if j <= 0.7 {
+		return j
+	}
+	l := 0
+	for l < 4 && l < len(ra) && l < len(rb) && ra[l] == rb[l] {
+		l++
+	}
+	return j + float64(l)*0.1*(1-j)
+}
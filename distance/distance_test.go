@@ -0,0 +1,105 @@
+package distance
+
+import (
+	"github.com/bmizerany/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.001
+}
+
+// --------------------- LEVENSHTEIN ------------------------
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, Levenshtein("kitten", "sitting"), 3)
+	assert.Equal(t, Levenshtein("", "abc"), 3)
+	assert.Equal(t, Levenshtein("abc", ""), 3)
+	assert.Equal(t, Levenshtein("", ""), 0)
+	assert.Equal(t, Levenshtein("José", "Jose"), 1)
+	assert.Equal(t, Levenshtein("日本語", "日本人"), 1)
+}
+
+func TestLevenshteinBounded(t *testing.T) {
+	assert.Equal(t, LevenshteinBounded("kitten", "sitting", 3), 3)
+	assert.Equal(t, LevenshteinBounded("kitten", "sitting", 2), 3)
+	assert.Equal(t, LevenshteinBounded("a", "abcdef", 2), 3)
+	assert.Equal(t, LevenshteinBounded("abc", "abd", -3), 1)
+	assert.Equal(t, LevenshteinBounded("abc", "abc", -3), 0)
+
+	rnd := rand.New(rand.NewSource(1))
+	alphabet := []rune("abcé")
+	random := func() string {
+		rs := make([]rune, rnd.Intn(12))
+		for i := range rs {
+			rs[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(rs)
+	}
+	var s Scratch
+	for i := 0; i < 2000; i++ {
+		a, b, k := random(), random(), rnd.Intn(8)
+		var expected int = min(Levenshtein(a, b), k+1)
+		assert.Equal(t, s.LevenshteinBounded(a, b, k), expected, a, b, k)
+	}
+}
+
+// --------------------- OSA / DAMERAU ------------------------
+func TestOSA(t *testing.T) {
+	assert.Equal(t, OSA("ca", "abc"), 3)
+	assert.Equal(t, OSA("abcdef", "abdcef"), 1)
+	assert.Equal(t, OSA("kitten", "sitting"), 3)
+	assert.Equal(t, OSA("ñé", "éñ"), 1)
+}
+
+func TestDamerauLevenshtein(t *testing.T) {
+	assert.Equal(t, DamerauLevenshtein("ca", "abc"), 2)
+	assert.Equal(t, DamerauLevenshtein("abcdef", "abdcef"), 1)
+	assert.Equal(t, DamerauLevenshtein("kitten", "sitting"), 3)
+	assert.Equal(t, DamerauLevenshtein("", "abc"), 3)
+	assert.Equal(t, DamerauLevenshtein("ñé", "éñ"), 1)
+}
+
+// --------------------- HAMMING ------------------------
+func TestHamming(t *testing.T) {
+	d, err := Hamming("karolin", "kathrin")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, d, 3)
+
+	d, err = Hamming("çava", "cava")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, d, 1)
+
+	_, err = Hamming("abc", "ab")
+	assert.Equal(t, err, ErrLengthMismatch)
+}
+
+// --------------------- JARO ------------------------
+func TestJaro(t *testing.T) {
+	assert.T(t, near(Jaro("MARTHA", "MARHTA"), 0.944))
+	assert.T(t, near(Jaro("DIXON", "DICKSONX"), 0.767))
+	assert.Equal(t, Jaro("", ""), 1.0)
+	assert.Equal(t, Jaro("abc", ""), 0.0)
+	assert.Equal(t, Jaro("abc", "xyz"), 0.0)
+}
+
+func TestJaroWinkler(t *testing.T) {
+	assert.T(t, near(JaroWinkler("MARTHA", "MARHTA"), 0.961))
+	assert.T(t, near(JaroWinkler("DIXON", "DICKSONX"), 0.813))
+	assert.Equal(t, JaroWinkler("Zoë", "Zoë"), 1.0)
+}
+
+// --------------------- SCRATCH ------------------------
+func TestScratchDoesNotAllocate(t *testing.T) {
+	var s Scratch
+	s.DamerauLevenshtein("Hélène Dupont", "Helene Dupond")
+	allocs := testing.AllocsPerRun(100, func() {
+		s.Levenshtein("Hélène Dupont", "Helene Dupond")
+		s.LevenshteinBounded("Hélène Dupont", "Helene Dupond", 3)
+		s.OSA("Hélène Dupont", "Helene Dupond")
+		s.DamerauLevenshtein("Hélène Dupont", "Helene Dupond")
+		s.JaroWinkler("Hélène Dupont", "Helene Dupond")
+	})
+	assert.Equal(t, allocs, 0.0)
+}
@@ -173,3 +173,10 @@ func ExampleAll() {
 
 	//Output: false
 }
+
+func ExampleDistinctFold() {
+	var str string = "aAbBcC"
+	fmt.Println(DistinctFold(str))
+
+	//Output: abc
+}
@@ -0,0 +1,46 @@
+package strex
+
+import (
+	"unicode"
+
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// DistinctFunc removes duplicate elements from a string, where two runes are
+// considered duplicates when key returns the same value for both. It keeps
+// only the first occurrence of each element.
+func DistinctFunc(key func(rune) rune, s string) string {
+	var seen runes.Set
+	t := make([]rune, 0, len(s))
+	for _, r := range s {
+		if seen.Add(key(r)) {
+			t = append(t, r)
+		}
+	}
+	return string(t)
+}
+
+// DistinctFold is like Distinct but compares runes under Unicode simple case
+// folding, so "aAbB" becomes "ab"
+func DistinctFold(s string) string {
+	return DistinctFunc(runes.FoldKey, s)
+}
+
+// DistinctFoldSpecial is like DistinctFold but applies the case mapping c
+// before folding, e.g. unicode.TurkishCase keeps dotted and dotless i apart
+func DistinctFoldSpecial(c unicode.SpecialCase, s string) string {
+	return DistinctFunc(runes.SpecialFoldKey(c), s)
+}
+
+// GroupFold is like Group but treats runes that are equal under Unicode
+// simple case folding as equal elements
+func GroupFold(s string) []string {
+	return GroupBy(func(a, b rune) bool { return runes.FoldKey(a) == runes.FoldKey(b) }, s)
+}
+
+// GroupFoldSpecial is like GroupFold but applies the case mapping c before
+// folding
+func GroupFoldSpecial(c unicode.SpecialCase, s string) []string {
+	key := runes.SpecialFoldKey(c)
+	return GroupBy(func(a, b rune) bool { return key(a) == key(b) }, s)
+}
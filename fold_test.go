@@ -0,0 +1,67 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"testing"
+	"unicode"
+)
+
+// --------------------- DISTINCTFOLD ------------------------
+func TestDistinctFold(t *testing.T) {
+	var input string = "aAbBcC"
+	var expected string = "abc"
+	var actual string = DistinctFold(input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestDistinctFoldWithOrbit(t *testing.T) {
+	//U+212A KELVIN SIGN and U+017F LATIN SMALL LETTER LONG S fold with k and s
+	var input string = "kKKsſS"
+	var expected string = "ks"
+	var actual string = DistinctFold(input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestDistinctFoldWithEmpty(t *testing.T) {
+	assert.Equal(t, DistinctFold(""), "")
+}
+
+func TestDistinctFoldSpecialTurkish(t *testing.T) {
+	//dotted and dotless i are different letters in Turkish
+	var input string = "iİıI"
+	var expected string = "iı"
+	var actual string = DistinctFoldSpecial(unicode.TurkishCase, input)
+	assert.Equal(t, actual, expected)
+
+	assert.Equal(t, DistinctFold("iI"), "i")
+	assert.Equal(t, DistinctFoldSpecial(unicode.TurkishCase, "iI"), "iI")
+}
+
+// --------------------- DISTINCTFUNC ------------------------
+func TestDistinctFunc(t *testing.T) {
+	var isDigit func(rune) rune = func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return '0'
+		}
+		return r
+	}
+	var input string = "a1b2c3"
+	var expected string = "a1bc"
+	var actual string = DistinctFunc(isDigit, input)
+	assert.Equal(t, actual, expected)
+}
+
+// --------------------- GROUPFOLD ------------------------
+func TestGroupFold(t *testing.T) {
+	var input string = "aAabBc"
+	var expected []string = []string{"aAa", "bB", "c"}
+	var actual []string = GroupFold(input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestGroupFoldSpecialTurkish(t *testing.T) {
+	var input string = "iİıI"
+	var expected []string = []string{"iİ", "ıI"}
+	var actual []string = GroupFoldSpecial(unicode.TurkishCase, input)
+	assert.Equal(t, actual, expected)
+}
@@ -0,0 +1,204 @@
+package strex
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// asciiNames are the names of the ASCII control characters used in Haskell
+// escapes, indexed by code point, with SP for the space
+var asciiNames = [...]string{
+	"NUL", "SOH", "STX", "ETX", "EOT", "ENQ", "ACK", "BEL",
+	"BS", "HT", "LF", "VT", "FF", "CR", "SO", "SI",
+	"DLE", "DC1", "DC2", "DC3", "DC4", "NAK", "SYN", "ETB",
+	"CAN", "EM", "SUB", "ESC", "FS", "GS", "RS", "US",
+	"SP",
+}
+
+// ShowHaskell returns s as a Haskell string literal, exactly as GHC's
+// show :: String -> String writes it. Runes above '\DEL' become decimal
+// escapes, control characters get their mnemonic escapes such as \SOH, and
+// \& is inserted where the next character would otherwise extend an escape,
+// so "\1234" followed by "5" is written "\1234\&5". Invalid UTF-8 is written
+// as U+FFFD.
+func ShowHaskell(s string) string {
+	var t strings.Builder
+	t.WriteByte('"')
+	// protect is the condition the next character must not meet, for the
+	// escape written last to be read back correctly
+	var protect func(rune) bool
+	for _, r := range s {
+		if protect != nil && protect(r) {
+			t.WriteString(`\&`)
+		}
+		protect = nil
+		switch {
+		case r > 0x7f:
+			t.WriteByte('\\')
+			t.WriteString(strconv.Itoa(int(r)))
+			protect = isASCIIDigit
+		case r == 0x7f:
+			t.WriteString(`\DEL`)
+		case r == '\\':
+			t.WriteString(`\\`)
+		case r == '"':
+			t.WriteString(`\"`)
+		case r >= ' ':
+			t.WriteRune(r)
+		case r == '\a':
+			t.WriteString(`\a`)
+		case r == '\b':
+			t.WriteString(`\b`)
+		case r == '\f':
+			t.WriteString(`\f`)
+		case r == '\n':
+			t.WriteString(`\n`)
+		case r == '\r':
+			t.WriteString(`\r`)
+		case r == '\t':
+			t.WriteString(`\t`)
+		case r == '\v':
+			t.WriteString(`\v`)
+		case r == 0x0e:
+			t.WriteString(`\SO`)
+			protect = func(c rune) bool { return c == 'H' }
+		default:
+			t.WriteByte('\\')
+			t.WriteString(asciiNames[r])
+		}
+	}
+	t.WriteByte('"')
+	return t.String()
+}
+
+func isASCIIDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+// haskellError returns the error for a malformed literal at offset i
+func haskellError(msg string, i int) error {
+	return errors.New("strex: invalid Haskell string literal: " + msg + " at offset " + strconv.Itoa(i))
+}
+
+// ReadHaskell parses a Haskell string literal, such as one written by
+// ShowHaskell or by GHC, and returns the string it denotes. It accepts every
+// escape of the Haskell 2010 report: character escapes, \^A style control
+// escapes, ASCII mnemonics, decimal, octal (\o) and hexadecimal (\x)
+// escapes, the empty escape \& and string gaps, which are a backslash,
+// white space and another backslash. The error gives the byte offset of the
+// first problem, including escapes of surrogates, which a Go string cannot
+// hold.
+func ReadHaskell(lit string) (string, error) {
+	if len(lit) < 2 || lit[0] != '"' || lit[len(lit)-1] != '"' {
+		return "", haskellError("missing quotes", 0)
+	}
+	var t strings.Builder
+	for i := 1; i < len(lit)-1; {
+		r, sz := utf8.DecodeRuneInString(lit[i:])
+		switch {
+		case r == utf8.RuneError && sz == 1:
+			return "", haskellError("invalid UTF-8", i)
+		case r == '"':
+			return "", haskellError("unescaped quote", i)
+		case r != '\\':
+			if unicode.IsControl(r) {
+				return "", haskellError("unescaped control character", i)
+			}
+			t.WriteRune(r)
+			i += sz
+			continue
+		}
+
+		// an escape, in the rest of the literal before the closing quote
+		rest := lit[i+1 : len(lit)-1]
+		if rest == "" {
+			return "", haskellError("incomplete escape", i)
+		}
+		c, csz := utf8.DecodeRuneInString(rest)
+		if unicode.IsSpace(c) {
+			// a string gap
+			end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsSpace(r) })
+			if end < 0 || rest[end] != '\\' {
+				return "", haskellError("unterminated string gap", i)
+			}
+			i += 1 + end + 1
+			continue
+		}
+		if e := strings.IndexByte(`abfnrtv\"'`, rest[0]); e >= 0 {
+			t.WriteByte("\a\b\f\n\r\t\v\\\"'"[e])
+			i += 2
+			continue
+		}
+		switch c {
+		case '&':
+			i += 2
+			continue
+		case '^':
+			if len(rest) < 2 || rest[1] < '@' || rest[1] > '_' {
+				return "", haskellError("invalid control escape", i)
+			}
+			t.WriteByte(rest[1] - '@')
+			i += 3
+			continue
+		}
+		if name, code := asciiEscape(rest); name != "" {
+			t.WriteByte(code)
+			i += 1 + len(name)
+			continue
+		}
+
+		base, digits := 10, rest
+		switch c {
+		case 'o':
+			base, digits = 8, rest[csz:]
+		case 'x':
+			base, digits = 16, rest[csz:]
+		}
+		n := 0
+		for n < len(digits) && digitIn(digits[n], base) {
+			n++
+		}
+		if n == 0 {
+			return "", haskellError("unknown escape", i)
+		}
+		v, err := strconv.ParseUint(digits[:n], base, 32)
+		if err != nil || v > unicode.MaxRune {
+			return "", haskellError("escape out of range", i)
+		}
+		if 0xd800 <= v && v < 0xe000 {
+			return "", haskellError("escape of a surrogate", i)
+		}
+		t.WriteRune(rune(v))
+		i += 1 + len(rest) - len(digits) + n
+	}
+	return t.String(), nil
+}
+
+// asciiEscape returns the longest ASCII mnemonic at the start of s, so that
+// "SOH" is read as one escape rather than "SO" followed by "H", and its code
+func asciiEscape(s string) (string, byte) {
+	best, code := "", byte(0)
+	for c, name := range asciiNames {
+		if len(name) > len(best) && strings.HasPrefix(s, name) {
+			best, code = name, byte(c)
+		}
+	}
+	if strings.HasPrefix(s, "DEL") {
+		best, code = "DEL", 0x7f
+	}
+	return best, code
+}
+
+// digitIn reports whether c is a digit in the given base
+func digitIn(c byte, base int) bool {
+	switch base {
+	case 8:
+		return '0' <= c && c <= '7'
+	case 16:
+		return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+	}
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,102 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// expected values are what GHC's show prints for the same String
+var haskellCases [][2]string = [][2]string{
+	{"", `""`},
+	{"hello world", `"hello world"`},
+	{"it's", `"it's"`},
+	{`say "hi"`, `"say \"hi\""`},
+	{`C:\dir`, `"C:\\dir"`},
+	{"\a\b\f\n\r\t\v", `"\a\b\f\n\r\t\v"`},
+	{"\x00\x01\x1b[0m\x1f\x7f", `"\NUL\SOH\ESC[0m\US\DEL"`},
+	{"\x0eH", `"\SO\&H"`},
+	{"\x0eI", `"\SOI"`},
+	{"\x0e\x0e", `"\SO\SO"`},
+	{"é", `"\233"`},
+	{"é1", `"\233\&1"`},
+	{"éa", `"\233a"`},
+	{"\u04d25", `"\1234\&5"`},
+	{"日本", `"\26085\26412"`},
+	{"\U0001F600", `"\128512"`},
+	{"\u0080", `"\128"`},
+	{"bad\xff", `"bad\65533"`},
+}
+
+// --------------------- SHOWHASKELL ------------------------
+func TestShowHaskell(t *testing.T) {
+	for _, c := range haskellCases {
+		assert.Equal(t, ShowHaskell(c[0]), c[1])
+	}
+}
+
+// --------------------- READHASKELL ------------------------
+func TestReadHaskell(t *testing.T) {
+	for _, c := range haskellCases {
+		s, err := ReadHaskell(c[1])
+		assert.Equal(t, err, nil)
+		assert.Equal(t, s, strings.ToValidUTF8(c[0], "\uFFFD"))
+	}
+}
+
+func TestReadHaskellEscapes(t *testing.T) {
+	cases := [][2]string{
+		{`"\SOH"`, "\x01"},
+		{`"\SO\&H"`, "\x0eH"},
+		{`"\SP\DEL"`, " \x7f"},
+		{`"\^@\^A\^Z\^["`, "\x00\x01\x1a\x1b"},
+		{`"\x41\x1F600\o101\65"`, "A\U0001F600AA"},
+		{`"\1114111"`, "\U0010FFFF"},
+		{`"\'"`, "'"},
+		{`"a\&b\&"`, "ab"},
+		{"\"abc\\   \n\t  \\def\"", "abcdef"},
+		{"\"\\\n\\\"", ""},
+	}
+	for _, c := range cases {
+		s, err := ReadHaskell(c[0])
+		assert.Equal(t, err, nil, c[0])
+		assert.Equal(t, s, c[1])
+	}
+}
+
+func TestReadHaskellErrors(t *testing.T) {
+	cases := [][2]string{
+		{`hello`, "missing quotes at offset 0"},
+		{`"`, "missing quotes at offset 0"},
+		{`"a"b"`, "unescaped quote at offset 2"},
+		{`"a\"`, "incomplete escape at offset 2"},
+		{`"\q"`, "unknown escape at offset 1"},
+		{`"\xg"`, "unknown escape at offset 1"},
+		{`"\^a"`, "invalid control escape at offset 1"},
+		{`"\1114112"`, "escape out of range at offset 1"},
+		{`"\99999999999999999999"`, "escape out of range at offset 1"},
+		{`"\55296"`, "escape of a surrogate at offset 1"},
+		{"\"ab\\  x\"", "unterminated string gap at offset 3"},
+		{"\"a\nb\"", "unescaped control character at offset 2"},
+		{"\"\xff\"", "invalid UTF-8 at offset 1"},
+	}
+	for _, c := range cases {
+		_, err := ReadHaskell(c[0])
+		assert.Equal(t, err.Error(), "strex: invalid Haskell string literal: "+c[1], c[0])
+	}
+}
+
+func TestHaskellRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	alphabet := []rune("aH1\x00\x0e\x7f\"\\'é日\U0001F600 \n&")
+	for i := 0; i < 1000; i++ {
+		rs := make([]rune, rnd.Intn(10))
+		for j := range rs {
+			rs[j] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		s, err := ReadHaskell(ShowHaskell(string(rs)))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, s, string(rs))
+	}
+}
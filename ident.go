@@ -0,0 +1,189 @@
+package strex
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// rune classes used to split identifiers
+const (
+	classSep = iota
+	classLower
+	classUpper
+	classDigit
+)
+
+func identClass(r rune) int {
+	switch {
+	case unicode.IsUpper(r) || unicode.IsTitle(r):
+		return classUpper
+	case unicode.IsLetter(r):
+		return classLower
+	case unicode.IsDigit(r):
+		return classDigit
+	}
+	return classSep
+}
+
+// sameIdentRun reports whether r continues a run that starts with r0.
+// Combining marks belong to the letter or digit before them.
+func sameIdentRun(r0, r rune) bool {
+	c := identClass(r0)
+	return c == identClass(r) || c != classSep && unicode.IsMark(r)
+}
+
+// lastLetter returns the byte offset in run of its last rune that is not a
+// combining mark
+func lastLetter(run string) int {
+	i := len(run)
+	for i > 0 {
+		r, n := utf8.DecodeLastRuneInString(run[:i])
+		i -= n
+		if !unicode.IsMark(r) {
+			break
+		}
+	}
+	return i
+}
+
+// SplitIdentifier splits an identifier written in camelCase, PascalCase,
+// snake_case, kebab-case or a mix of them into its words. A run of capitals
+// is kept together as an acronym, except for its last letter when that starts
+// a new word, so "HTTPServer" gives ["HTTP" "Server"]. Digits stay with the
+// word before them, and combining marks with the rune before them.
+func SplitIdentifier(s string) []string {
+	runs := GroupBy(sameIdentRun, s)
+	words := []string{}
+	attach := false // whether a digit run may join the previous word
+	for i := 0; i < len(runs); i++ {
+		run := runs[i]
+		switch identClass(Head(run)) {
+		case classSep:
+			attach = false
+			continue
+		case classDigit:
+			if attach {
+				words[len(words)-1] += run
+				continue
+			}
+			words = append(words, run)
+		case classUpper:
+			if i+1 < len(runs) && identClass(Head(runs[i+1])) == classLower {
+				n := lastLetter(run)
+				if n > 0 {
+					words = append(words, run[:n])
+				}
+				words = append(words, run[n:]+runs[i+1])
+				i++
+			} else {
+				words = append(words, run)
+			}
+		case classLower:
+			words = append(words, run)
+		}
+		attach = true
+	}
+	return words
+}
+
+// Caser converts identifiers between naming conventions, writing the
+// acronyms it was given in their preferred spelling
+type Caser struct {
+	acronyms map[string]string
+}
+
+// NewCaser returns a Caser that preserves the given acronyms, such as "HTTP"
+// or "ID", when it capitalizes words. Acronyms are matched regardless of
+// case.
+func NewCaser(acronyms ...string) *Caser {
+	c := &Caser{acronyms: make(map[string]string, len(acronyms))}
+	for _, a := range acronyms {
+		c.acronyms[strings.ToUpper(a)] = a
+	}
+	return c
+}
+
+var defaultCaser = NewCaser()
+
+// title capitalizes the first rune of w and lowercases the rest, unless w is
+// a known acronym
+func (c *Caser) title(w string) string {
+	if a, ok := c.acronyms[strings.ToUpper(w)]; ok {
+		return a
+	}
+	r, n := utf8.DecodeRuneInString(w)
+	return string(unicode.ToTitle(r)) + strings.ToLower(w[n:])
+}
+
+func (c *Caser) join(words []string, sep string, f func(int, string) string) string {
+	for i, w := range words {
+		words[i] = f(i, w)
+	}
+	return strings.Join(words, sep)
+}
+
+// ToCamel converts s to camelCase
+func (c *Caser) ToCamel(s string) string {
+	return c.join(SplitIdentifier(s), "", func(i int, w string) string {
+		if i == 0 {
+			return strings.ToLower(w)
+		}
+		return c.title(w)
+	})
+}
+
+// ToPascal converts s to PascalCase
+func (c *Caser) ToPascal(s string) string {
+	return c.join(SplitIdentifier(s), "", func(_ int, w string) string { return c.title(w) })
+}
+
+// ToSnake converts s to snake_case
+func (c *Caser) ToSnake(s string) string {
+	return c.join(SplitIdentifier(s), "_", func(_ int, w string) string { return strings.ToLower(w) })
+}
+
+// ToKebab converts s to kebab-case
+func (c *Caser) ToKebab(s string) string {
+	return c.join(SplitIdentifier(s), "-", func(_ int, w string) string { return strings.ToLower(w) })
+}
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE
+func (c *Caser) ToScreamingSnake(s string) string {
+	return c.join(SplitIdentifier(s), "_", func(_ int, w string) string { return strings.ToUpper(w) })
+}
+
+// ToTitleWords converts s to capitalized words separated by spaces
+func (c *Caser) ToTitleWords(s string) string {
+	return c.join(SplitIdentifier(s), " ", func(_ int, w string) string { return c.title(w) })
+}
+
+// ToCamel converts s to camelCase
+func ToCamel(s string) string {
+	return defaultCaser.ToCamel(s)
+}
+
+// ToPascal converts s to PascalCase
+func ToPascal(s string) string {
+	return defaultCaser.ToPascal(s)
+}
+
+// ToSnake converts s to snake_case
+func ToSnake(s string) string {
+	return defaultCaser.ToSnake(s)
+}
+
+// ToKebab converts s to kebab-case
+func ToKebab(s string) string {
+	return defaultCaser.ToKebab(s)
+}
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE
+func ToScreamingSnake(s string) string {
+	return defaultCaser.ToScreamingSnake(s)
+}
+
+// ToTitleWords converts s to capitalized words separated by spaces
+func ToTitleWords(s string) string {
+	return defaultCaser.ToTitleWords(s)
+}
@@ -0,0 +1,55 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"testing"
+)
+
+// --------------------- SPLITIDENTIFIER ------------------------
+func TestSplitIdentifier(t *testing.T) {
+	var tests map[string][]string = map[string][]string{
+		"camelCase":        {"camel", "Case"},
+		"PascalCase":       {"Pascal", "Case"},
+		"snake_case":       {"snake", "case"},
+		"kebab-case":       {"kebab", "case"},
+		"HTTPServer":       {"HTTP", "Server"},
+		"getHTTPResponse":  {"get", "HTTP", "Response"},
+		"userID":           {"user", "ID"},
+		"utf8Decode":       {"utf8", "Decode"},
+		"HTTP2Server":      {"HTTP2", "Server"},
+		"version_2":        {"version", "2"},
+		"__private__field": {"private", "field"},
+		"ÉtéChaud":         {"Été", "Chaud"},
+		"cafe\u0301Noir":   {"cafe\u0301", "Noir"},
+		"CAFE\u0301Noir":   {"CAFE\u0301", "Noir"},
+		"E\u0301te\u0301":  {"E\u0301te\u0301"},
+		"नमस्ते_दुनिया":    {"नमस्ते", "दुनिया"},
+		"A":                {"A"},
+		"":                 {},
+	}
+	for input, expected := range tests {
+		assert.Equal(t, SplitIdentifier(input), expected, input)
+	}
+}
+
+// --------------------- CASE CONVERSION ------------------------
+func TestToCase(t *testing.T) {
+	var input string = "parseHTTPRequest_id"
+	assert.Equal(t, ToCamel(input), "parseHttpRequestId")
+	assert.Equal(t, ToPascal(input), "ParseHttpRequestId")
+	assert.Equal(t, ToSnake(input), "parse_http_request_id")
+	assert.Equal(t, ToKebab(input), "parse-http-request-id")
+	assert.Equal(t, ToScreamingSnake(input), "PARSE_HTTP_REQUEST_ID")
+	assert.Equal(t, ToTitleWords(input), "Parse Http Request Id")
+	assert.Equal(t, ToSnake("नमस्ते_दुनिया"), "नमस्ते_दुनिया")
+}
+
+func TestCaserAcronyms(t *testing.T) {
+	var c *Caser = NewCaser("HTTP", "ID", "iOS")
+	var input string = "parse_http_request_id"
+	assert.Equal(t, c.ToCamel(input), "parseHTTPRequestID")
+	assert.Equal(t, c.ToPascal(input), "ParseHTTPRequestID")
+	assert.Equal(t, c.ToTitleWords("ios_app"), "iOS App")
+	assert.Equal(t, c.ToCamel("http_server"), "httpServer")
+	assert.Equal(t, c.ToSnake("HTTPServerID"), "http_server_id")
+}
@@ -0,0 +1,313 @@
+package strex
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Index is a suffix array over a string, for answering many substring
+// queries against the same large text.
+//
+// Building an Index takes linear time using the SA-IS algorithm. After that
+// Contains, Count and Locate take O(m log n) time for a pattern of m bytes,
+// independent of the number of occurrences. Offsets returned by an Index are
+// byte offsets; RuneOffset and ByteOffset convert them for use with Take and
+// Drop.
+type Index struct {
+	s    string
+	sa   []int32
+	lcp  []int32
+	text *Text
+}
+
+// NewIndex builds an Index over s
+func NewIndex(s string) *Index {
+	seq := make([]int32, len(s))
+	for i := 0; i < len(s); i++ {
+		seq[i] = int32(s[i])
+	}
+	sa := saIs(seq, 255)
+	return &Index{s: s, sa: sa, lcp: kasai(seq, sa), text: NewText(s)}
+}
+
+// String returns the indexed string
+func (x *Index) String() string {
+	return x.s
+}
+
+// RuneOffset converts a byte offset in the indexed string to a rune offset
+func (x *Index) RuneOffset(b int) int {
+	return x.text.RuneIndex(b)
+}
+
+// ByteOffset converts a rune offset in the indexed string to a byte offset
+func (x *Index) ByteOffset(r int) int {
+	return x.text.ByteOffset(r)
+}
+
+// lookup returns the range of the suffix array holding the suffixes that
+// start with sub
+func (x *Index) lookup(sub string) (int, int) {
+	lo := sort.Search(len(x.sa), func(i int) bool {
+		return x.s[x.sa[i]:] >= sub
+	})
+	hi := lo + sort.Search(len(x.sa)-lo, func(i int) bool {
+		return !strings.HasPrefix(x.s[x.sa[lo+i]:], sub)
+	})
+	return lo, hi
+}
+
+// Contains reports whether sub occurs in the indexed string
+func (x *Index) Contains(sub string) bool {
+	lo, hi := x.lookup(sub)
+	return hi > lo
+}
+
+// Count returns the number of possibly overlapping occurrences of sub in the
+// indexed string. An empty sub occurs at every byte offset but not at the
+// end, so unlike strings.Count, which gives the rune count plus one, Count("")
+// is the length of the string in bytes, matching Locate("").
+func (x *Index) Count(sub string) int {
+	lo, hi := x.lookup(sub)
+	return hi - lo
+}
+
+// Locate returns the sorted byte offsets of all possibly overlapping
+// occurrences of sub in the indexed string
+func (x *Index) Locate(sub string) []int {
+	lo, hi := x.lookup(sub)
+	offs := make([]int, 0, hi-lo)
+	for _, o := range x.sa[lo:hi] {
+		offs = append(offs, int(o))
+	}
+	slices.Sort(offs)
+	return offs
+}
+
+// LocateRunes is like Locate but returns rune offsets
+func (x *Index) LocateRunes(sub string) []int {
+	offs := x.Locate(sub)
+	for i, o := range offs {
+		offs[i] = x.RuneOffset(o)
+	}
+	return offs
+}
+
+// LongestRepeated returns the longest substring that occurs at least twice
+// in the indexed string, possibly overlapping. Ties are broken in favour of
+// the substring that sorts first.
+func (x *Index) LongestRepeated() string {
+	best := ""
+	for i := 1; i < len(x.sa); i++ {
+		if t := runePrefix(x.s, int(x.sa[i]), int(x.lcp[i])); len(t) > len(best) {
+			best = t
+		}
+	}
+	return best
+}
+
+// LongestCommonSubstring returns the longest string that is a substring of
+// both a and b. It never splits a rune.
+func LongestCommonSubstring(a, b string) string {
+	seq := make([]int32, 0, len(a)+len(b)+1)
+	for i := 0; i < len(a); i++ {
+		seq = append(seq, int32(a[i]))
+	}
+	seq = append(seq, 256)
+	for i := 0; i < len(b); i++ {
+		seq = append(seq, int32(b[i]))
+	}
+	sa := saIs(seq, 256)
+	lcp := kasai(seq, sa)
+	best := ""
+	for i := 1; i < len(sa); i++ {
+		p, q := int(sa[i-1]), int(sa[i])
+		if (p < len(a)) == (q < len(a)) {
+			continue
+		}
+		if t := runePrefix(a, min(p, q), int(lcp[i])); len(t) > len(best) {
+			best = t
+		}
+	}
+	return best
+}
+
+// runePrefix returns s[i:i+n] shortened so that it neither starts nor ends
+// inside a rune, or "" if i is not at the start of a rune
+func runePrefix(s string, i, n int) string {
+	if n == 0 || i >= len(s) || !utf8.RuneStart(s[i]) {
+		return ""
+	}
+	end := i + n
+	for end > i && end < len(s) && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[i:end]
+}
+
+// kasai returns the longest common prefix array of s and its suffix array
+// sa, where lcp[i] is the length of the common prefix of the suffixes at
+// sa[i-1] and sa[i], and lcp[0] is 0
+func kasai(s []int32, sa []int32) []int32 {
+	n := len(s)
+	rank := make([]int32, n)
+	for i, p := range sa {
+		rank[p] = int32(i)
+	}
+	lcp := make([]int32, n)
+	h := 0
+	for i := 0; i < n; i++ {
+		if h > 0 {
+			h--
+		}
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := int(sa[rank[i]-1])
+		for i+h < n && j+h < n && s[i+h] == s[j+h] {
+			h++
+		}
+		lcp[rank[i]] = int32(h)
+	}
+	return lcp
+}
+
+// saIs returns the suffix array of s, whose elements must lie in
+// [0, upper], using the SA-IS algorithm of Nong, Zhang and Chan
+func saIs(s []int32, upper int) []int32 {
+	n := len(s)
+	switch n {
+	case 0:
+		return []int32{}
+	case 1:
+		return []int32{0}
+	case 2:
+		if s[0] < s[1] {
+			return []int32{0, 1}
+		}
+		return []int32{1, 0}
+	}
+
+	sa := make([]int32, n)
+	ls := make([]bool, n) // true for S-type positions
+	for i := n - 2; i >= 0; i-- {
+		if s[i] == s[i+1] {
+			ls[i] = ls[i+1]
+		} else {
+			ls[i] = s[i] < s[i+1]
+		}
+	}
+
+	// bucket boundaries for L-type and S-type positions of each character
+	sumL := make([]int32, upper+1)
+	sumS := make([]int32, upper+1)
+	for i := 0; i < n; i++ {
+		if !ls[i] {
+			sumS[s[i]]++
+		} else {
+			sumL[s[i]+1]++
+		}
+	}
+	for i := 0; i <= upper; i++ {
+		sumS[i] += sumL[i]
+		if i < upper {
+			sumL[i+1] += sumS[i]
+		}
+	}
+
+	buf := make([]int32, upper+1)
+	induce := func(lms []int32) {
+		for i := range sa {
+			sa[i] = -1
+		}
+		copy(buf, sumS)
+		for _, d := range lms {
+			if int(d) == n {
+				continue
+			}
+			sa[buf[s[d]]] = d
+			buf[s[d]]++
+		}
+		copy(buf, sumL)
+		sa[buf[s[n-1]]] = int32(n - 1)
+		buf[s[n-1]]++
+		for i := 0; i < n; i++ {
+			v := sa[i]
+			if v >= 1 && !ls[v-1] {
+				sa[buf[s[v-1]]] = v - 1
+				buf[s[v-1]]++
+			}
+		}
+		copy(buf, sumL)
+		for i := n - 1; i >= 0; i-- {
+			v := sa[i]
+			if v >= 1 && ls[v-1] {
+				buf[s[v-1]+1]--
+				sa[buf[s[v-1]+1]] = v - 1
+			}
+		}
+	}
+
+	// leftmost S-type positions
+	lmsMap := make([]int32, n+1)
+	for i := range lmsMap {
+		lmsMap[i] = -1
+	}
+	var lms []int32
+	for i := 1; i < n; i++ {
+		if !ls[i-1] && ls[i] {
+			lmsMap[i] = int32(len(lms))
+			lms = append(lms, int32(i))
+		}
+	}
+	m := len(lms)
+	induce(lms)
+
+	if m > 0 {
+		sorted := make([]int32, 0, m)
+		for _, v := range sa {
+			if lmsMap[v] != -1 {
+				sorted = append(sorted, v)
+			}
+		}
+		// name the LMS substrings and sort them recursively
+		rec := make([]int32, m)
+		recUpper := 0
+		rec[lmsMap[sorted[0]]] = 0
+		for i := 1; i < m; i++ {
+			l, r := int(sorted[i-1]), int(sorted[i])
+			endL, endR := n, n
+			if k := lmsMap[l] + 1; int(k) < m {
+				endL = int(lms[k])
+			}
+			if k := lmsMap[r] + 1; int(k) < m {
+				endR = int(lms[k])
+			}
+			same := true
+			if endL-l != endR-r {
+				same = false
+			} else {
+				for l < endL && s[l] == s[r] {
+					l++
+					r++
+				}
+				if l == n || s[l] != s[r] {
+					same = false
+				}
+			}
+			if !same {
+				recUpper++
+			}
+			rec[lmsMap[sorted[i]]] = int32(recUpper)
+		}
+		recSa := saIs(rec, recUpper)
+		for i := 0; i < m; i++ {
+			sorted[i] = lms[recSa[i]]
+		}
+		induce(sorted)
+	}
+	return sa
+}
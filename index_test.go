@@ -0,0 +1,96 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// naiveSuffixArray sorts the suffixes of s directly
+func naiveSuffixArray(s []int32) []int32 {
+	sa := make([]int32, len(s))
+	for i := range sa {
+		sa[i] = int32(i)
+	}
+	slices.SortFunc(sa, func(a, b int32) int {
+		return slices.Compare(s[a:], s[b:])
+	})
+	return sa
+}
+
+// --------------------- SAIS ------------------------
+func TestSaIsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		upper := 1 + rnd.Intn(4)
+		s := make([]int32, rnd.Intn(60))
+		for j := range s {
+			s[j] = int32(rnd.Intn(upper + 1))
+		}
+		assert.Equal(t, saIs(s, upper), naiveSuffixArray(s), s)
+	}
+}
+
+// --------------------- INDEX ------------------------
+func TestIndex(t *testing.T) {
+	var x *Index = NewIndex("banana")
+	assert.Equal(t, x.Contains("nan"), true)
+	assert.Equal(t, x.Contains("nab"), false)
+	assert.Equal(t, x.Count("ana"), 2)
+	assert.Equal(t, x.Locate("a"), []int{1, 3, 5})
+	assert.Equal(t, x.Locate("x"), []int{})
+	assert.Equal(t, x.LongestRepeated(), "ana")
+}
+
+func TestIndexRunes(t *testing.T) {
+	var input string = "日本語と日本"
+	var x *Index = NewIndex(input)
+	assert.Equal(t, x.Locate("日本"), []int{0, 12})
+	assert.Equal(t, x.LocateRunes("日本"), []int{0, 4})
+	assert.Equal(t, Drop(x.RuneOffset(12), input), "日本")
+	assert.Equal(t, x.ByteOffset(4), 12)
+	assert.Equal(t, x.LongestRepeated(), "日本")
+	assert.Equal(t, x.Count(""), len(input))
+	assert.Equal(t, len(x.Locate("")), len(input))
+}
+
+func TestIndexLongestRepeatedDoesNotSplitRunes(t *testing.T) {
+	//é and è share their first byte
+	var x *Index = NewIndex("aé aè")
+	assert.Equal(t, x.LongestRepeated(), "a")
+}
+
+func TestIndexRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	var alphabet []rune = []rune("abé")
+	random := func(n int) string {
+		rs := make([]rune, n)
+		for i := range rs {
+			rs[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(rs)
+	}
+	for i := 0; i < 200; i++ {
+		s, sub := random(rnd.Intn(50)), random(1+rnd.Intn(3))
+		x := NewIndex(s)
+		assert.Equal(t, x.Contains(sub), strings.Contains(s, sub))
+		var expected []int = []int{}
+		for j := 0; j+len(sub) <= len(s); j++ {
+			if strings.HasPrefix(s[j:], sub) {
+				expected = append(expected, j)
+			}
+		}
+		assert.Equal(t, x.Locate(sub), expected)
+		assert.Equal(t, x.Count(sub), len(expected))
+	}
+}
+
+// --------------------- LONGESTCOMMONSUBSTRING ------------------------
+func TestLongestCommonSubstring(t *testing.T) {
+	assert.Equal(t, LongestCommonSubstring("xabcdey", "zbcdq"), "bcd")
+	assert.Equal(t, LongestCommonSubstring("été", "thé"), "é")
+	assert.Equal(t, LongestCommonSubstring("abc", "xyz"), "")
+	assert.Equal(t, LongestCommonSubstring("", "xyz"), "")
+}
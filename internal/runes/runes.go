@@ -0,0 +1,279 @@
+// Package runes holds the rune handling shared by strex and bytex. Its
+// functions are generic over strings and byte slices, so the two packages
+// treat invalid UTF-8 and case folding identically.
+package runes
+
+import (
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Bytes is a string or a byte slice
+type Bytes interface {
+	~string | ~[]byte
+}
+
+// DecodeRune is utf8.DecodeRune for a string or a byte slice
+func DecodeRune[S Bytes](s S) (rune, int) {
+	if len(s) > 0 && s[0] < utf8.RuneSelf {
+		return rune(s[0]), 1
+	}
+	var buf [utf8.UTFMax]byte
+	n := copy(buf[:], s)
+	return utf8.DecodeRune(buf[:n])
+}
+
+// DecodeLastRune is utf8.DecodeLastRune for a string or a byte slice
+func DecodeLastRune[S Bytes](s S) (rune, int) {
+	if len(s) > 0 && s[len(s)-1] < utf8.RuneSelf {
+		return rune(s[len(s)-1]), 1
+	}
+	var buf [utf8.UTFMax]byte
+	n := copy(buf[:], s[max(0, len(s)-utf8.UTFMax):])
+	return utf8.DecodeLastRune(buf[:n])
+}
+
+// Set is a set of runes that only allocates once it holds more than a
+// handful of non-ASCII runes. The zero value is an empty set.
+type Set struct {
+	ascii [utf8.RuneSelf]bool
+	small [16]rune
+	n     int
+	large map[rune]bool
+}
+
+// Add puts r in the set and reports whether it was not there before
+func (rs *Set) Add(r rune) bool {
+	if 0 <= r && r < utf8.RuneSelf {
+		if rs.ascii[r] {
+			return false
+		}
+		rs.ascii[r] = true
+		return true
+	}
+	if rs.large != nil {
+		if rs.large[r] {
+			return false
+		}
+		rs.large[r] = true
+		return true
+	}
+	for _, x := range rs.small[:rs.n] {
+		if x == r {
+			return false
+		}
+	}
+	if rs.n < len(rs.small) {
+		rs.small[rs.n] = r
+		rs.n++
+		return true
+	}
+	rs.large = make(map[rune]bool)
+	for _, x := range rs.small {
+		rs.large[x] = true
+	}
+	rs.large[r] = true
+	return true
+}
+
+// FoldKey returns a canonical representative of the unicode.SimpleFold orbit
+// containing r, so that two runes are equal under simple case folding exactly
+// when their fold keys are equal
+func FoldKey(r rune) rune {
+	if r < 0x80 {
+		if 'a' <= r && r <= 'z' {
+			return r - ('a' - 'A')
+		}
+		return r
+	}
+	k := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < k {
+			k = f
+		}
+	}
+	return k
+}
+
+// SpecialFoldKey returns a fold key for r that first applies the case mapping
+// of c, so that language specific rules such as unicode.TurkishCase are honoured
+func SpecialFoldKey(c unicode.SpecialCase) func(rune) rune {
+	return func(r rune) rune {
+		return FoldKey(c.ToLower(r))
+	}
+}
+
+// Equal compares runes exactly
+func Equal(a, b rune) bool {
+	return a == b
+}
+
+// EqualFold compares runes under simple case folding
+func EqualFold(a, b rune) bool {
+	return a == b || FoldKey(a) == FoldKey(b)
+}
+
+// PrefixLen returns the lengths in bytes of the longest common prefix of a
+// and b, compared rune by rune with eq. The lengths differ only when eq
+// treats runes of different encoded lengths as equal.
+func PrefixLen[S Bytes](eq func(rune, rune) bool, a, b S) (int, int) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] < utf8.RuneSelf && a[i] == b[j] {
+			i++
+			j++
+			continue
+		}
+		sa, sb, ok := sameRune(eq, a[i:], b[j:])
+		if !ok {
+			break
+		}
+		i += sa
+		j += sb
+	}
+	return i, j
+}
+
+// SuffixLen is the suffix version of PrefixLen
+func SuffixLen[S Bytes](eq func(rune, rune) bool, a, b S) (int, int) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		_, la := DecodeLastRune(a[:len(a)-i])
+		_, lb := DecodeLastRune(b[:len(b)-j])
+		sa, sb, ok := sameRune(eq, a[len(a)-i-la:len(a)-i], b[len(b)-j-lb:len(b)-j])
+		if !ok {
+			break
+		}
+		i += sa
+		j += sb
+	}
+	return i, j
+}
+
+// sameRune compares the first runes of a and b with eq and returns their
+// sizes. An invalid byte only matches the same invalid byte.
+func sameRune[S Bytes](eq func(rune, rune) bool, a, b S) (int, int, bool) {
+	ra, sa := DecodeRune(a)
+	rb, sb := DecodeRune(b)
+	badA, badB := ra == utf8.RuneError && sa == 1, rb == utf8.RuneError && sb == 1
+	if badA || badB {
+		return sa, sb, badA && badB && a[0] == b[0]
+	}
+	return sa, sb, eq(ra, rb)
+}
+
+// CommonPrefix returns the longest prefix of ss[0] that every element of ss
+// starts with, compared rune by rune with eq, or the zero value if ss is
+// empty
+func CommonPrefix[S Bytes](eq func(rune, rune) bool, ss []S) S {
+	var p S
+	if len(ss) == 0 {
+		return p
+	}
+	p = ss[0]
+	for _, s := range ss[1:] {
+		n, _ := PrefixLen(eq, p, s)
+		p = p[:n]
+	}
+	return p
+}
+
+// CommonSuffix is the suffix version of CommonPrefix
+func CommonSuffix[S Bytes](eq func(rune, rune) bool, ss []S) S {
+	var p S
+	if len(ss) == 0 {
+		return p
+	}
+	p = ss[0]
+	for _, s := range ss[1:] {
+		n, _ := SuffixLen(eq, p, s)
+		p = p[len(p)-n:]
+	}
+	return p
+}
+
+// AppendReverse appends the runes of s in reverse order to dst and returns
+// the extended buffer
+func AppendReverse[S Bytes](dst []byte, s S) []byte {
+	for len(s) > 0 {
+		n := 1
+		if s[len(s)-1] >= utf8.RuneSelf {
+			_, n = DecodeLastRune(s)
+			dst = append(dst, s[len(s)-n:]...)
+		} else {
+			dst = append(dst, s[len(s)-1])
+		}
+		s = s[:len(s)-n]
+	}
+	return dst
+}
+
+// WriteReverse writes the runes of s in reverse order to w
+func WriteReverse[S Bytes](w io.Writer, s S) (int, error) {
+	var buf [256]byte
+	written := 0
+	for len(s) > 0 {
+		// move the cut forward to the start of a rune, but no further than
+		// a valid rune can reach, so that a long run of stray continuation
+		// bytes still makes progress
+		cut := max(0, len(s)-len(buf))
+		for k := 1; cut > 0 && k < utf8.UTFMax && !utf8.RuneStart(s[cut]); k++ {
+			cut++
+		}
+		n, err := w.Write(AppendReverse(buf[:0], s[cut:]))
+		written += n
+		if err != nil {
+			return written, err
+		}
+		s = s[:cut]
+	}
+	return written, nil
+}
+
+// write writes s to w without copying it
+func write[S Bytes](w io.Writer, s S) (int, error) {
+	if b, ok := any(s).([]byte); ok {
+		return w.Write(b)
+	}
+	return io.WriteString(w, string(s))
+}
+
+// WriteRuns writes the runes of s for which keep returns true to w, passing
+// each run of kept runes to w in a single call. Like strings.Map it writes
+// invalid UTF-8 as utf8.RuneError.
+func WriteRuns[S Bytes](w io.Writer, keep func(rune) bool, s S) (int, error) {
+	written, start := 0, 0
+	for i := 0; i < len(s); {
+		r, sz := DecodeRune(s[i:])
+		k := keep(r)
+		if k && (r != utf8.RuneError || sz > 1) {
+			i += sz
+			continue
+		}
+		if start < i {
+			n, err := write(w, s[start:i])
+			written += n
+			if err != nil {
+				return written, err
+			}
+		}
+		if k {
+			n, err := io.WriteString(w, string(utf8.RuneError))
+			written += n
+			if err != nil {
+				return written, err
+			}
+		}
+		i += sz
+		start = i
+	}
+	if start < len(s) {
+		n, err := write(w, s[start:])
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,49 @@
+package runes
+
+import (
+	"github.com/bmizerany/assert"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var decodeInputs []string = []string{"", "a", "é", "世", "😀", "\xff", "\xe4\xb8", "a\x80", "\x80\x80\x80\x80\x80", "世\xbf", "\U0010FFFF"}
+
+func TestDecodeRune(t *testing.T) {
+	for _, s := range decodeInputs {
+		r, n := utf8.DecodeRuneInString(s)
+		gr, gn := DecodeRune(s)
+		assert.Equal(t, []int{int(gr), gn}, []int{int(r), n}, s)
+		gr, gn = DecodeRune([]byte(s))
+		assert.Equal(t, []int{int(gr), gn}, []int{int(r), n}, s)
+	}
+}
+
+func TestDecodeLastRune(t *testing.T) {
+	for _, s := range decodeInputs {
+		r, n := utf8.DecodeLastRuneInString(s)
+		gr, gn := DecodeLastRune(s)
+		assert.Equal(t, []int{int(gr), gn}, []int{int(r), n}, s)
+		gr, gn = DecodeLastRune([]byte(s))
+		assert.Equal(t, []int{int(gr), gn}, []int{int(r), n}, s)
+	}
+}
+
+func TestWriteRuns(t *testing.T) {
+	keep := func(r rune) bool { return r != 'x' }
+	for _, s := range decodeInputs {
+		var a, b strings.Builder
+		n, err := WriteRuns(&a, keep, s+"x"+s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, n, a.Len())
+		_, err = WriteRuns(&b, keep, []byte(s+"x"+s))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, b.String(), a.String())
+		assert.Equal(t, a.String(), strings.Map(func(r rune) rune {
+			if keep(r) {
+				return r
+			}
+			return -1
+		}, s+"x"+s))
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Package latin1 provides counterparts of the strex functions for ISO-8859-1
+text held as []byte.
+
+Every byte of Latin-1 is one rune, the byte value being the code point, so
+rune offsets are byte offsets and no function needs to decode anything.
+Functions that return part of their argument return a subslice of it.
+*/
+package latin1
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// Head returns the first rune of s, which must be non-empty
+func Head(s []byte) rune {
+	if len(s) == 0 {
+		panic("empty list")
+	}
+	return rune(s[0])
+}
+
+// Tail returns s without its first rune. s must be non-empty.
+func Tail(s []byte) []byte {
+	if len(s) == 0 {
+		panic("empty list")
+	}
+	return s[1:]
+}
+
+// Take returns the first n runes of s, or s itself if it is shorter
+func Take(n int, s []byte) []byte {
+	return s[:max(0, min(n, len(s)))]
+}
+
+// Drop returns s without its first n runes, or an empty slice if it is
+// shorter
+func Drop(n int, s []byte) []byte {
+	return s[max(0, min(n, len(s))):]
+}
+
+// Reverse returns the runes of s in reverse order
+func Reverse(s []byte) []byte {
+	t := make([]byte, len(s))
+	for i, c := range s {
+		t[len(s)-1-i] = c
+	}
+	return t
+}
+
+// Filter returns the runes of s that satisfy p
+func Filter(p func(rune) bool, s []byte) []byte {
+	t := make([]byte, 0, len(s))
+	for _, c := range s {
+		if p(rune(c)) {
+			t = append(t, c)
+		}
+	}
+	return t
+}
+
+// Group splits s into runs of equal runes. The runs are subslices of s.
+func Group(s []byte) [][]byte {
+	groups := [][]byte{}
+	for len(s) > 0 {
+		n := 1
+		for n < len(s) && s[n] == s[0] {
+			n++
+		}
+		groups = append(groups, s[:n])
+		s = s[n:]
+	}
+	return groups
+}
+
+// Distinct returns the first occurrence of each rune of s
+func Distinct(s []byte) []byte {
+	var seen [256]bool
+	t := make([]byte, 0, min(len(s), len(seen)))
+	for _, c := range s {
+		if !seen[c] {
+			seen[c] = true
+			t = append(t, c)
+		}
+	}
+	return t
+}
+
+// RangeError lists the runes that FromString could not represent in
+// Latin-1
+type RangeError struct {
+	Offsets []int // byte offsets in the string
+}
+
+func (e *RangeError) Error() string {
+	msg := "latin1: rune outside Latin-1 at offset " + strconv.Itoa(e.Offsets[0])
+	if n := len(e.Offsets) - 1; n > 0 {
+		msg += " and " + strconv.Itoa(n) + " more"
+	}
+	return msg
+}
+
+// ToString converts s to a Go string. Every Latin-1 text can be converted.
+func ToString(s []byte) string {
+	b := make([]byte, 0, len(s))
+	for _, c := range s {
+		if c < utf8.RuneSelf {
+			b = append(b, c)
+		} else {
+			b = utf8.AppendRune(b, rune(c))
+		}
+	}
+	return string(b)
+}
+
+// FromString converts s to Latin-1. Runes above U+00FF, including the
+// U+FFFD that invalid UTF-8 decodes as, become '?' and their byte offsets
+// are reported in a *RangeError.
+func FromString(s string) ([]byte, error) {
+	t := make([]byte, 0, len(s))
+	var bad []int
+	for i, r := range s {
+		if r > 0xff {
+			r = '?'
+			bad = append(bad, i)
+		}
+		t = append(t, byte(r))
+	}
+	if bad != nil {
+		return t, &RangeError{Offsets: bad}
+	}
+	return t, nil
+}
@@ -0,0 +1,56 @@
+package latin1
+
+import (
+	"github.com/bmizerany/assert"
+	"github.com/djhworld/strex"
+	"testing"
+	"unicode"
+)
+
+var inputs []string = []string{"", "a", "hello", "héllo wörld", "aaÿÿb©©", "Ünïcödé"}
+
+func encode(t *testing.T, s string) []byte {
+	b, err := FromString(s)
+	assert.Equal(t, err, nil)
+	return b
+}
+
+// --------------------- STREX ------------------------
+func TestMatchesStrex(t *testing.T) {
+	for _, s := range inputs {
+		b := encode(t, s)
+		for n := -1; n <= len(s)+1; n++ {
+			assert.Equal(t, ToString(Take(n, b)), strex.Take(n, s))
+			assert.Equal(t, ToString(Drop(n, b)), strex.Drop(n, s))
+		}
+		assert.Equal(t, ToString(Reverse(b)), strex.Reverse(s))
+		assert.Equal(t, ToString(Filter(unicode.IsLetter, b)), strex.Filter(unicode.IsLetter, s))
+		assert.Equal(t, ToString(Distinct(b)), strex.Distinct(s))
+		groups := []string{}
+		for _, g := range Group(b) {
+			groups = append(groups, ToString(g))
+		}
+		assert.Equal(t, groups, strex.Group(s))
+		if s != "" {
+			assert.Equal(t, Head(b), strex.Head(s))
+			assert.Equal(t, ToString(Tail(b)), strex.Tail(s))
+		}
+	}
+}
+
+// --------------------- CONVERSION ------------------------
+func TestConversion(t *testing.T) {
+	assert.Equal(t, encode(t, "é"), []byte{0xe9})
+	assert.Equal(t, ToString([]byte{'c', 0xe9, 0xff}), "céÿ")
+
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+	assert.Equal(t, encode(t, ToString(all)), all)
+
+	b, err := FromString("a€b\xff")
+	assert.Equal(t, b, []byte("a?b?"))
+	assert.Equal(t, err.(*RangeError).Offsets, []int{1, 5})
+	assert.Equal(t, err.Error(), "latin1: rune outside Latin-1 at offset 1 and 1 more")
+}
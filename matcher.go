@@ -0,0 +1,235 @@
+package strex
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// MatchKind selects which match a Matcher reports when several patterns
+// match at the same position
+type MatchKind int
+
+const (
+	// LeftmostFirst prefers the pattern that comes first in the pattern list,
+	// like alternation in a regular expression
+	LeftmostFirst MatchKind = iota
+	// LeftmostLongest prefers the longest pattern
+	LeftmostLongest
+)
+
+// MatcherOptions configures a Matcher. The zero value gives case sensitive
+// LeftmostFirst matching.
+type MatcherOptions struct {
+	Kind     MatchKind
+	FoldCase bool // compare runes under Unicode simple case folding
+}
+
+// Match is an occurrence of a pattern in a string
+type Match struct {
+	Pattern   int // index of the pattern in the list given to NewMatcher
+	Start     int // byte offset of the first rune of the match
+	End       int // byte offset just after the last rune of the match
+	RuneStart int // rune offset of the first rune of the match
+	RuneEnd   int // rune offset just after the last rune of the match
+}
+
+// Matcher searches a string for many patterns at once using the
+// Aho-Corasick algorithm, in time proportional to the length of the string
+// rather than to the number of patterns. A Matcher is safe for concurrent
+// use.
+type Matcher struct {
+	nodes  []acNode
+	lens   []int // length in runes of each pattern
+	maxLen int
+	opts   MatcherOptions
+}
+
+type acNode struct {
+	next    map[rune]int32
+	fail    int32
+	dict    int32 // nearest node on the fail chain that ends a pattern, or -1
+	pattern int32 // pattern ending at this node, or -1
+}
+
+// NewMatcher compiles patterns into a Matcher. Empty patterns never match.
+func NewMatcher(patterns []string, opts MatcherOptions) *Matcher {
+	m := &Matcher{lens: make([]int, len(patterns)), opts: opts}
+	m.nodes = append(m.nodes, acNode{dict: -1, pattern: -1})
+	for p, pat := range patterns {
+		n := int32(0)
+		for _, r := range pat {
+			r = m.key(r)
+			next, ok := m.nodes[n].next[r]
+			if !ok {
+				next = int32(len(m.nodes))
+				m.nodes = append(m.nodes, acNode{dict: -1, pattern: -1})
+				if m.nodes[n].next == nil {
+					m.nodes[n].next = make(map[rune]int32)
+				}
+				m.nodes[n].next[r] = next
+			}
+			n = next
+			m.lens[p]++
+		}
+		if n != 0 && m.nodes[n].pattern < 0 {
+			m.nodes[n].pattern = int32(p)
+		}
+		m.maxLen = max(m.maxLen, m.lens[p])
+	}
+
+	// breadth first, so that fail links always point to finished nodes
+	queue := []int32{0}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for r, c := range m.nodes[n].next {
+			if n != 0 {
+				m.nodes[c].fail = m.step(m.nodes[n].fail, r)
+			}
+			f := m.nodes[c].fail
+			if m.nodes[f].pattern >= 0 {
+				m.nodes[c].dict = f
+			} else {
+				m.nodes[c].dict = m.nodes[f].dict
+			}
+			queue = append(queue, c)
+		}
+	}
+	return m
+}
+
+func (m *Matcher) key(r rune) rune {
+	if m.opts.FoldCase {
+		return runes.FoldKey(r)
+	}
+	return r
+}
+
+// step returns the node reached from n on rune r
+func (m *Matcher) step(n int32, r rune) int32 {
+	for {
+		if next, ok := m.nodes[n].next[r]; ok {
+			return next
+		}
+		if n == 0 {
+			return 0
+		}
+		n = m.nodes[n].fail
+	}
+}
+
+// IsInfixOfAny reports whether any of the patterns occurs in s
+func (m *Matcher) IsInfixOfAny(s string) bool {
+	n := int32(0)
+	for _, r := range s {
+		n = m.step(n, m.key(r))
+		if m.nodes[n].pattern >= 0 || m.nodes[n].dict >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// better reports whether match a should be preferred over match b
+func (m *Matcher) better(a, b Match) bool {
+	if a.RuneStart != b.RuneStart {
+		return a.RuneStart < b.RuneStart
+	}
+	if m.opts.Kind == LeftmostLongest && a.RuneEnd != b.RuneEnd {
+		return a.RuneEnd > b.RuneEnd
+	}
+	return a.Pattern < b.Pattern
+}
+
+// each calls f for every non-overlapping match in s from left to right,
+// stopping early if f returns false
+func (m *Matcher) each(s string, f func(Match) bool) {
+	if m.maxLen == 0 {
+		return
+	}
+	// byte offsets of the last maxLen+1 rune positions
+	offs := make([]int, m.maxLen+1)
+	n, i, ri := int32(0), 0, 0
+	offs[0] = 0
+	var best Match
+	found := false
+	for {
+		// no match can start at or before best once we are maxLen runes past it
+		if found && (i == len(s) || ri+1 > best.RuneStart+m.maxLen) {
+			if !f(best) {
+				return
+			}
+			n, i, ri, found = 0, best.End, best.RuneEnd, false
+			offs[ri%len(offs)] = i
+			continue
+		}
+		if i == len(s) {
+			return
+		}
+		r, sz := utf8.DecodeRuneInString(s[i:])
+		n = m.step(n, m.key(r))
+		i += sz
+		ri++
+		offs[ri%len(offs)] = i
+		for o := n; o >= 0; o = m.nodes[o].dict {
+			p := m.nodes[o].pattern
+			if p < 0 {
+				continue
+			}
+			rs := ri - m.lens[p]
+			c := Match{Pattern: int(p), Start: offs[rs%len(offs)], End: i, RuneStart: rs, RuneEnd: ri}
+			if !found || m.better(c, best) {
+				best, found = c, true
+			}
+		}
+	}
+}
+
+// FindAll returns all non-overlapping matches in s from left to right
+func (m *Matcher) FindAll(s string) []Match {
+	ms := []Match{}
+	m.each(s, func(x Match) bool {
+		ms = append(ms, x)
+		return true
+	})
+	return ms
+}
+
+// Find returns the leftmost match in s, and false if there is none
+func (m *Matcher) Find(s string) (Match, bool) {
+	var first Match
+	found := false
+	m.each(s, func(x Match) bool {
+		first, found = x, true
+		return false
+	})
+	return first, found
+}
+
+// Replace returns a copy of s in which every match found by FindAll is
+// replaced by the result of repl
+func (m *Matcher) Replace(s string, repl func(Match) string) string {
+	var t strings.Builder
+	last := 0
+	m.each(s, func(x Match) bool {
+		t.WriteString(s[last:x.Start])
+		t.WriteString(repl(x))
+		last = x.End
+		return true
+	})
+	if last == 0 {
+		return s
+	}
+	t.WriteString(s[last:])
+	return t.String()
+}
+
+// Mask returns a copy of s in which every rune of every match is replaced by
+// mask, so "a darn shame" with the pattern "darn" becomes "a **** shame"
+func (m *Matcher) Mask(s string, mask rune) string {
+	return m.Replace(s, func(x Match) string {
+		return strings.Repeat(string(mask), x.RuneEnd-x.RuneStart)
+	})
+}
@@ -0,0 +1,98 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// naiveFindAll is a slow reference implementation of Matcher.FindAll
+func naiveFindAll(patterns []string, kind MatchKind, s string) []Match {
+	ms := []Match{}
+	for i, ri := 0, 0; i < len(s); {
+		best := -1
+		for p, pat := range patterns {
+			if pat == "" || !strings.HasPrefix(s[i:], pat) {
+				continue
+			}
+			if best < 0 || (kind == LeftmostLongest && len(pat) > len(patterns[best])) {
+				best = p
+			}
+		}
+		if best < 0 {
+			_, sz := utf8.DecodeRuneInString(s[i:])
+			i += sz
+			ri++
+			continue
+		}
+		n := utf8.RuneCountInString(patterns[best])
+		ms = append(ms, Match{Pattern: best, Start: i, End: i + len(patterns[best]), RuneStart: ri, RuneEnd: ri + n})
+		i += len(patterns[best])
+		ri += n
+	}
+	return ms
+}
+
+// --------------------- MATCHER ------------------------
+func TestMatcherFindAll(t *testing.T) {
+	var m *Matcher = NewMatcher([]string{"he", "she", "his", "hers"}, MatcherOptions{})
+	var expected []Match = []Match{
+		{Pattern: 1, Start: 1, End: 4, RuneStart: 1, RuneEnd: 4},
+		{Pattern: 2, Start: 8, End: 11, RuneStart: 8, RuneEnd: 11},
+	}
+	assert.Equal(t, m.FindAll("ushers, his"), expected)
+	assert.Equal(t, m.IsInfixOfAny("ushers"), true)
+	assert.Equal(t, m.IsInfixOfAny("nothing"), false)
+}
+
+func TestMatcherKinds(t *testing.T) {
+	var patterns []string = []string{"Sam", "Samwise"}
+	var first *Matcher = NewMatcher(patterns, MatcherOptions{Kind: LeftmostFirst})
+	var longest *Matcher = NewMatcher(patterns, MatcherOptions{Kind: LeftmostLongest})
+	assert.Equal(t, first.FindAll("Samwise")[0].Pattern, 0)
+	assert.Equal(t, longest.FindAll("Samwise")[0].Pattern, 1)
+}
+
+func TestMatcherRuneOffsets(t *testing.T) {
+	var m *Matcher = NewMatcher([]string{"日本"}, MatcherOptions{})
+	var expected []Match = []Match{{Pattern: 0, Start: 3, End: 9, RuneStart: 2, RuneEnd: 4}}
+	assert.Equal(t, m.FindAll("né日本"), expected)
+}
+
+func TestMatcherFoldCase(t *testing.T) {
+	var m *Matcher = NewMatcher([]string{"darn", "ÉTÉ"}, MatcherOptions{FoldCase: true})
+	assert.Equal(t, m.Mask("Darn it, what a DARN été", '*'), "**** it, what a **** ***")
+	assert.Equal(t, m.IsInfixOfAny("DaRn"), true)
+}
+
+func TestMatcherReplace(t *testing.T) {
+	var m *Matcher = NewMatcher([]string{"cat", "dog"}, MatcherOptions{})
+	var actual string = m.Replace("cat and dog", func(x Match) string { return strings.ToUpper(Take(1, "cat and dog"[x.Start:x.End])) })
+	assert.Equal(t, actual, "C and D")
+	assert.Equal(t, m.Replace("bird", func(Match) string { return "" }), "bird")
+}
+
+func TestMatcherRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var alphabet []rune = []rune("abé")
+	random := func(n int) string {
+		rs := make([]rune, n)
+		for i := range rs {
+			rs[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(rs)
+	}
+	for i := 0; i < 300; i++ {
+		patterns := make([]string, 1+rnd.Intn(6))
+		for p := range patterns {
+			patterns[p] = random(rnd.Intn(5))
+		}
+		s := random(rnd.Intn(40))
+		for _, kind := range []MatchKind{LeftmostFirst, LeftmostLongest} {
+			m := NewMatcher(patterns, MatcherOptions{Kind: kind})
+			assert.Equal(t, m.FindAll(s), naiveFindAll(patterns, kind, s), patterns, s, kind)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package strex
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// digitValue returns the value of the decimal digit r. Unicode assigns the
+// digits of every script to consecutive code points starting with zero, so
+// the value is the distance from the start of the run of digits.
+func digitValue(r rune) int {
+	if r < utf8.RuneSelf {
+		return int(r - '0')
+	}
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+	return int(r-start) % 10
+}
+
+// compareNumbers compares two runs of decimal digits by value. It also
+// returns the difference in the number of leading zeros, which is used to
+// order "01" and "1" when nothing else tells them apart.
+func compareNumbers(a, b string) (int, int) {
+	isZero := func(r rune) bool { return unicode.IsDigit(r) && digitValue(r) == 0 }
+	za, zb := TakeWhile(isZero, a), TakeWhile(isZero, b)
+	a, b = a[len(za):], b[len(zb):]
+	zeros := utf8.RuneCountInString(za) - utf8.RuneCountInString(zb)
+	if na, nb := utf8.RuneCountInString(a), utf8.RuneCountInString(b); na != nb {
+		if na < nb {
+			return -1, zeros
+		}
+		return 1, zeros
+	}
+	for a != "" {
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if da, db := digitValue(ra), digitValue(rb); da != db {
+			if da < db {
+				return -1, zeros
+			}
+			return 1, zeros
+		}
+		a, b = a[sa:], b[sb:]
+	}
+	return 0, zeros
+}
+
+func naturalCompare(key func(rune) rune, a, b string) int {
+	zeros := 0
+	for a != "" && b != "" {
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if unicode.IsDigit(ra) && unicode.IsDigit(rb) {
+			var na, nb string
+			na, a = Span(unicode.IsDigit, a)
+			nb, b = Span(unicode.IsDigit, b)
+			c, z := compareNumbers(na, nb)
+			if c != 0 {
+				return c
+			}
+			if zeros == 0 {
+				zeros = z
+			}
+			continue
+		}
+		if ka, kb := key(ra), key(rb); ka != kb {
+			if ka < kb {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[sa:], b[sb:]
+	}
+	switch {
+	case a != "":
+		return 1
+	case b != "":
+		return -1
+	case zeros < 0:
+		return -1
+	case zeros > 0:
+		return 1
+	}
+	return 0
+}
+
+// NaturalCompare compares a and b in natural order, where runs of decimal
+// digits compare by their numeric value, so "file2" sorts before "file10".
+// Numbers of any length are supported and digits of all scripts count. It
+// returns -1, 0 or +1 and can be passed to slices.SortFunc.
+func NaturalCompare(a, b string) int {
+	if c := naturalCompare(func(r rune) rune { return r }, a, b); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// NaturalLess reports whether a sorts before b in natural order
+func NaturalLess(a, b string) bool {
+	return NaturalCompare(a, b) < 0
+}
+
+// NaturalCompareFold is like NaturalCompare but ignores case, so "File2"
+// and "file2" compare equal
+func NaturalCompareFold(a, b string) int {
+	return naturalCompare(func(r rune) rune { return unicode.ToLower(runes.FoldKey(r)) }, a, b)
+}
+
+// NaturalLessFold reports whether a sorts before b in case-insensitive
+// natural order
+func NaturalLessFold(a, b string) bool {
+	return NaturalCompareFold(a, b) < 0
+}
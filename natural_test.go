@@ -0,0 +1,52 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"slices"
+	"testing"
+)
+
+// --------------------- NATURALCOMPARE ------------------------
+func TestNaturalCompare(t *testing.T) {
+	assert.Equal(t, NaturalCompare("file2", "file10"), -1)
+	assert.Equal(t, NaturalCompare("file10", "file2"), 1)
+	assert.Equal(t, NaturalCompare("file10", "file10"), 0)
+	assert.Equal(t, NaturalCompare("a", "a1"), -1)
+	assert.Equal(t, NaturalCompare("", ""), 0)
+}
+
+func TestNaturalCompareLeadingZeros(t *testing.T) {
+	assert.Equal(t, NaturalCompare("v007", "v7"), 1)
+	assert.Equal(t, NaturalCompare("v007", "v8"), -1)
+	assert.Equal(t, NaturalCompare("v01.10", "v1.9"), 1)
+}
+
+func TestNaturalCompareLongNumbers(t *testing.T) {
+	var big string = "id123456789012345678901234567890"
+	var bigger string = "id123456789012345678901234567891"
+	assert.Equal(t, NaturalCompare(big, bigger), -1)
+	assert.Equal(t, NaturalCompare("id99999999999999999999", bigger), -1)
+}
+
+func TestNaturalCompareOtherScripts(t *testing.T) {
+	//Arabic-Indic digits: ٢ is 2 and ١٠ is 10
+	assert.Equal(t, NaturalCompare("page ٢", "page ١٠"), -1)
+	assert.Equal(t, NaturalCompare("page ٢", "page 10"), -1)
+	//Mathematical monospace digits come after four other runs of digits
+	assert.Equal(t, digitValue('𝟿'), 9)
+	assert.Equal(t, digitValue('𝟶'), 0)
+}
+
+func TestNaturalLessSort(t *testing.T) {
+	var input []string = []string{"img12.png", "img10.png", "IMG2.png", "img2.png", "img1.png"}
+	var expected []string = []string{"IMG2.png", "img1.png", "img2.png", "img10.png", "img12.png"}
+	var actual []string = slices.Clone(input)
+	slices.SortFunc(actual, NaturalCompare)
+	assert.Equal(t, actual, expected)
+
+	var expectedFold []string = []string{"img1.png", "IMG2.png", "img2.png", "img10.png", "img12.png"}
+	slices.SortStableFunc(input, NaturalCompareFold)
+	assert.Equal(t, input, expectedFold)
+	assert.Equal(t, NaturalLessFold("File2", "file10"), true)
+	assert.Equal(t, NaturalLess("b", "a"), false)
+}
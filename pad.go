@@ -0,0 +1,91 @@
+package strex
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// padding returns n runes taken from fill repeated as often as needed. An
+// empty fill pads with spaces.
+func padding(n int, fill string) string {
+	if n <= 0 {
+		return ""
+	}
+	if fill == "" {
+		fill = " "
+	}
+	k := utf8.RuneCountInString(fill)
+	return Take(n, strings.Repeat(fill, (n+k-1)/k))
+}
+
+// PadLeft returns s preceded by enough runes from fill to make it n runes
+// long. It returns s unchanged if it already has at least n runes.
+func PadLeft(s string, n int, fill string) string {
+	return padding(n-utf8.RuneCountInString(s), fill) + s
+}
+
+// PadRight returns s followed by enough runes from fill to make it n runes
+// long. It returns s unchanged if it already has at least n runes.
+func PadRight(s string, n int, fill string) string {
+	return s + padding(n-utf8.RuneCountInString(s), fill)
+}
+
+// Center returns s with runes from fill added on both sides to make it n
+// runes long. When the padding cannot be split evenly the extra rune goes on
+// the right. It returns s unchanged if it already has at least n runes.
+func Center(s string, n int, fill string) string {
+	k := n - utf8.RuneCountInString(s)
+	if k <= 0 {
+		return s
+	}
+	return padding(k/2, fill) + s + padding(k-k/2, fill)
+}
+
+// Justify joins words with spaces, spreading extra spaces between them so
+// that the result is exactly width runes long. The leftmost gaps get the
+// larger share when the spaces cannot be spread evenly. A single word is
+// padded on the right, and words that do not fit are joined by single spaces.
+func Justify(words []string, width int) string {
+	if len(words) == 1 {
+		return PadRight(words[0], width, " ")
+	}
+	n := 0
+	for _, w := range words {
+		n += utf8.RuneCountInString(w)
+	}
+	gaps := len(words) - 1
+	spaces := width - n
+	if gaps <= 0 || spaces < gaps {
+		return strings.Join(words, " ")
+	}
+	var t strings.Builder
+	for i, w := range words {
+		if i > 0 {
+			k := spaces / gaps
+			if i <= spaces%gaps {
+				k++
+			}
+			t.WriteString(strings.Repeat(" ", k))
+		}
+		t.WriteString(w)
+	}
+	return t.String()
+}
+
+// FitExact returns s truncated or padded with spaces so that it is exactly n
+// runes long. A truncated s ends with ellipsis, which is itself truncated if
+// it has more than n runes.
+func FitExact(s string, n int, ellipsis string) string {
+	if n <= 0 {
+		return ""
+	}
+	c := utf8.RuneCountInString(s)
+	if c <= n {
+		return s + strings.Repeat(" ", n-c)
+	}
+	e := utf8.RuneCountInString(ellipsis)
+	if e >= n {
+		return Take(n, ellipsis)
+	}
+	return Take(n-e, s) + ellipsis
+}
@@ -0,0 +1,49 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"testing"
+	"unicode/utf8"
+)
+
+// --------------------- PADLEFT / PADRIGHT ------------------------
+func TestPadLeft(t *testing.T) {
+	assert.Equal(t, PadLeft("42", 5, "0"), "00042")
+	assert.Equal(t, PadLeft("日本", 4, ""), "  日本")
+	assert.Equal(t, PadLeft("toolong", 3, "."), "toolong")
+}
+
+func TestPadRight(t *testing.T) {
+	assert.Equal(t, PadRight("né", 5, "."), "né...")
+	assert.Equal(t, PadRight("a", 6, "-="), "a-=-=-")
+}
+
+// --------------------- CENTER ------------------------
+func TestCenter(t *testing.T) {
+	assert.Equal(t, Center("hi", 6, "*"), "**hi**")
+	assert.Equal(t, Center("hi", 7, "*"), "**hi***")
+	assert.Equal(t, Center("título", 10, "─═"), "─═título─═")
+	assert.Equal(t, Center("abc", 2, "*"), "abc")
+}
+
+// --------------------- JUSTIFY ------------------------
+func TestJustify(t *testing.T) {
+	var input []string = []string{"the", "quick", "fox"}
+	var expected string = "the    quick   fox"
+	var actual string = Justify(input, 18)
+	assert.Equal(t, actual, expected)
+	assert.Equal(t, utf8.RuneCountInString(Justify([]string{"été", "à", "noël"}, 15)), 15)
+	assert.Equal(t, Justify([]string{"alone"}, 8), "alone   ")
+	assert.Equal(t, Justify([]string{"too", "long"}, 5), "too long")
+	assert.Equal(t, Justify(nil, 5), "")
+}
+
+// --------------------- FITEXACT ------------------------
+func TestFitExact(t *testing.T) {
+	assert.Equal(t, FitExact("hello world", 8, "…"), "hello w…")
+	assert.Equal(t, FitExact("日本語", 5, "…"), "日本語  ")
+	assert.Equal(t, FitExact("日本語です", 4, "..."), "日...")
+	assert.Equal(t, FitExact("abcdef", 2, "..."), "..")
+	assert.Equal(t, FitExact("abc", 3, "…"), "abc")
+	assert.Equal(t, FitExact("abc", 0, "…"), "")
+}
@@ -0,0 +1,176 @@
+/*
+Package parallel provides concurrent counterparts of strex.Filter, strex.All,
+strex.Any, strex.Count and strex.Distinct for very large strings.
+
+The input is split into chunks that start on rune boundaries, the chunks are
+processed by a pool of goroutines and the results are merged in chunk order,
+so every function returns exactly what its sequential counterpart returns,
+including for invalid UTF-8. The predicate is called from several goroutines
+at once and must be safe for concurrent use.
+
+Cancellation of the context is checked between chunks. A cancelled call
+returns the zero value and the error of the context.
+*/
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/djhworld/strex"
+)
+
+// Options configures how the work is split. The zero value uses one worker
+// per CPU and chunks of about 1 MiB.
+type Options struct {
+	Workers   int // number of goroutines, GOMAXPROCS if zero or negative
+	ChunkSize int // approximate number of bytes per chunk, 1 MiB if zero or negative
+}
+
+func (o Options) workers() int {
+	if o.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return o.Workers
+}
+
+func (o Options) chunkSize() int {
+	if o.ChunkSize <= 0 {
+		return 1 << 20
+	}
+	return o.ChunkSize
+}
+
+// chunks splits s into pieces of at least size bytes, each of which starts
+// at the first byte of a rune. Decoding the pieces one after another gives
+// the same runes as decoding s, since no rune contains a byte that can start
+// a rune.
+func chunks(s string, size int) []string {
+	var cs []string
+	for len(s) > size {
+		i := size
+		for i < len(s) && !utf8.RuneStart(s[i]) {
+			i++
+		}
+		cs = append(cs, s[:i])
+		s = s[i:]
+	}
+	if s != "" || len(cs) == 0 {
+		cs = append(cs, s)
+	}
+	return cs
+}
+
+// run calls f on every chunk of s and returns the results in chunk order.
+// Once f reports that the answer is known no further chunks are started and
+// the error is nil; otherwise the error is that of ctx if it was done before
+// every chunk was processed.
+func run[T any](ctx context.Context, s string, opts Options, f func(string) (T, bool)) ([]T, error) {
+	cs := chunks(s, opts.chunkSize())
+	out := make([]T, len(cs))
+	var next, done atomic.Int64
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	for w := min(opts.workers(), len(cs)); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() && ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= len(cs) {
+					return
+				}
+				var known bool
+				out[i], known = f(cs[i])
+				if known {
+					stop.Store(true)
+				}
+				done.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if stop.Load() || int(done.Load()) == len(cs) {
+		return out, nil
+	}
+	return nil, ctx.Err()
+}
+
+// Filter is like strex.Filter
+func Filter(ctx context.Context, p func(rune) bool, s string, opts Options) (string, error) {
+	parts, err := run(ctx, s, opts, func(c string) (string, bool) {
+		return strex.Filter(p, c), false
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(parts, ""), nil
+}
+
+// All is like strex.All. It stops as soon as any worker finds a rune that
+// does not satisfy p.
+func All(ctx context.Context, p func(rune) bool, s string, opts Options) (bool, error) {
+	oks, err := run(ctx, s, opts, func(c string) (bool, bool) {
+		ok := strex.All(p, c)
+		return ok, !ok
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, ok := range oks {
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Any is like strex.Any. It stops as soon as any worker finds a rune that
+// satisfies p.
+func Any(ctx context.Context, p func(rune) bool, s string, opts Options) (bool, error) {
+	found, err := run(ctx, s, opts, func(c string) (bool, bool) {
+		ok := strex.Any(p, c)
+		return ok, ok
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, ok := range found {
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Count is like strex.Count
+func Count(ctx context.Context, p func(rune) bool, s string, opts Options) (int, error) {
+	counts, err := run(ctx, s, opts, func(c string) (int, bool) {
+		return strex.Count(p, c), false
+	})
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, k := range counts {
+		n += k
+	}
+	return n, nil
+}
+
+// Distinct is like strex.Distinct. Each chunk is reduced to its distinct
+// runes concurrently, and since Distinct keeps first occurrences, the
+// distinct runes of the concatenation of those reductions are the result.
+func Distinct(ctx context.Context, s string, opts Options) (string, error) {
+	parts, err := run(ctx, s, opts, func(c string) (string, bool) {
+		return strex.Distinct(c), false
+	})
+	if err != nil {
+		return "", err
+	}
+	return strex.Distinct(strings.Join(parts, "")), nil
+}
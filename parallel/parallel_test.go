@@ -0,0 +1,116 @@
+package parallel
+
+import (
+	"context"
+	"github.com/bmizerany/assert"
+	"github.com/djhworld/strex"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"unicode"
+)
+
+var bg = context.Background()
+
+func random(rnd *rand.Rand, n int) string {
+	pieces := []string{"a", "b", "Z", " ", "é", "世", "\U0001F600", "\xff", "\xe4\xb8"}
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteString(pieces[rnd.Intn(len(pieces))])
+	}
+	return b.String()
+}
+
+// --------------------- CHUNKS ------------------------
+func TestChunks(t *testing.T) {
+	assert.Equal(t, chunks("", 4), []string{""})
+	assert.Equal(t, chunks("abcdefghij", 4), []string{"abcd", "efgh", "ij"})
+	assert.Equal(t, chunks("abc世界", 4), []string{"abc世", "界"})
+	assert.Equal(t, chunks("世界", 1), []string{"世", "界"})
+}
+
+// --------------------- SEQUENTIAL ------------------------
+func TestMatchesSequential(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	isUpper := unicode.IsUpper
+	isLetter := unicode.IsLetter
+	for i := 0; i < 300; i++ {
+		s := random(rnd, rnd.Intn(200))
+		opts := Options{Workers: 1 + rnd.Intn(4), ChunkSize: 1 + rnd.Intn(16)}
+
+		f, err := Filter(bg, isLetter, s, opts)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, f, strex.Filter(isLetter, s))
+
+		a, err := All(bg, isLetter, s, opts)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, a, strex.All(isLetter, s))
+
+		y, err := Any(bg, isUpper, s, opts)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, y, strex.Any(isUpper, s))
+
+		c, err := Count(bg, isLetter, s, opts)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, c, strex.Count(isLetter, s))
+
+		d, err := Distinct(bg, s, opts)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, d, strex.Distinct(s))
+	}
+}
+
+func TestDefaultOptions(t *testing.T) {
+	s := strings.Repeat("abc世界", 1000)
+	f, err := Filter(bg, unicode.IsLetter, s, Options{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, f, s)
+}
+
+// --------------------- CANCELLATION ------------------------
+func TestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(bg)
+	cancel()
+	s := strings.Repeat("abc", 100)
+	opts := Options{ChunkSize: 8}
+
+	f, err := Filter(ctx, unicode.IsLetter, s, opts)
+	assert.Equal(t, err, context.Canceled)
+	assert.Equal(t, f, "")
+
+	_, err = All(ctx, unicode.IsLetter, s, opts)
+	assert.Equal(t, err, context.Canceled)
+	_, err = Any(ctx, unicode.IsLetter, s, opts)
+	assert.Equal(t, err, context.Canceled)
+	_, err = Count(ctx, unicode.IsLetter, s, opts)
+	assert.Equal(t, err, context.Canceled)
+	_, err = Distinct(ctx, s, opts)
+	assert.Equal(t, err, context.Canceled)
+}
+
+func TestCancelDuringRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(bg)
+	var calls atomic.Int64
+	p := func(r rune) bool {
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+		return true
+	}
+	_, err := Count(ctx, p, strings.Repeat("a", 1000), Options{Workers: 2, ChunkSize: 10})
+	assert.Equal(t, err, context.Canceled)
+	assert.T(t, calls.Load() < 1000)
+}
+
+func TestAnyStopsEarly(t *testing.T) {
+	var calls atomic.Int64
+	p := func(r rune) bool {
+		calls.Add(1)
+		return r == 'x'
+	}
+	found, err := Any(bg, p, "x"+strings.Repeat("a", 1000), Options{Workers: 1, ChunkSize: 10})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, found, true)
+	assert.Equal(t, calls.Load(), int64(1))
+}
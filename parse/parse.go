@@ -0,0 +1,354 @@
+/*
+Package parse is a small parser combinator library in the style of Haskell's
+Parsec, built on top of strex.
+
+A Parser reads runes from a State and either returns a value or a
+*ParseError. As in Parsec, a parser that fails after consuming input commits
+the whole alternative it is part of; wrap it in Try to allow backtracking.
+Errors carry the position at which they happened and the set of things that
+were expected there.
+*/
+package parse
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/djhworld/strex"
+)
+
+// Parser parses a value of type T from the input held by a State. When it
+// fails the returned error is a *ParseError.
+type Parser[T any] func(st *State) (T, error)
+
+// State is the input of a parser together with the current position. It
+// embeds a strex.Cursor so that custom parsers can read runes directly.
+type State struct {
+	*strex.Cursor
+	hint *ParseError
+}
+
+// ParseError describes why and where parsing failed
+type ParseError struct {
+	Pos        strex.Pos
+	Unexpected string   // what was found, e.g. `"x"` or "end of input"
+	Expected   []string // what would have been accepted instead
+	Message    string   // optional free form explanation
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Pos.String())
+	b.WriteString(": ")
+	parts := []string{}
+	if e.Unexpected != "" {
+		parts = append(parts, "unexpected "+e.Unexpected)
+	}
+	if n := len(e.Expected); n > 0 {
+		exp := e.Expected[0]
+		if n > 1 {
+			exp = strings.Join(e.Expected[:n-1], ", ") + " or " + e.Expected[n-1]
+		}
+		parts = append(parts, "expecting "+exp)
+	}
+	if e.Message != "" {
+		parts = append(parts, e.Message)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "unknown parse error")
+	}
+	b.WriteString(strings.Join(parts, ", "))
+	return b.String()
+}
+
+// union returns the sorted set of strings in a and b
+func union(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	u := []string{}
+	for _, s := range append(append([]string{}, a...), b...) {
+		if !seen[s] {
+			seen[s] = true
+			u = append(u, s)
+		}
+	}
+	sort.Strings(u)
+	return u
+}
+
+// merge combines two errors. The error that got further wins, and errors at
+// the same position have their expected sets joined.
+func merge(a, b *ParseError) *ParseError {
+	switch {
+	case a == nil:
+		return b
+	case b == nil || a.Pos.Offset > b.Pos.Offset:
+		return a
+	case a.Pos.Offset < b.Pos.Offset:
+		return b
+	}
+	m := *b
+	m.Expected = union(a.Expected, b.Expected)
+	if m.Message == "" {
+		m.Message = a.Message
+	}
+	return &m
+}
+
+// Fail returns a *ParseError at the current position saying that one of
+// expected was wanted. Alternatives that failed earlier at the same position
+// are included in the expected set.
+func (st *State) Fail(expected ...string) error {
+	e := &ParseError{Pos: st.Pos(), Expected: union(nil, expected)}
+	if r := st.Peek(); r == strex.EOF {
+		e.Unexpected = "end of input"
+	} else {
+		e.Unexpected = strconv.Quote(string(r))
+	}
+	if st.hint != nil && st.hint.Pos.Offset == e.Pos.Offset {
+		e = merge(st.hint, e)
+	}
+	return e
+}
+
+// remember records an error that a combinator chose to ignore, so that it
+// shows up in the expected set of a later error at the same position
+func (st *State) remember(err error) {
+	if e, ok := err.(*ParseError); ok {
+		if st.hint != nil && st.hint.Pos.Offset == e.Pos.Offset {
+			e = merge(st.hint, e)
+		}
+		st.hint = e
+	}
+}
+
+// Parse runs p over s. It does not require p to consume the whole input; use
+// Skip(p, Eof()) for that.
+func Parse[T any](p Parser[T], s string) (T, error) {
+	return p(&State{Cursor: strex.NewCursor(s)})
+}
+
+// Pure returns a parser that consumes nothing and returns v
+func Pure[T any](v T) Parser[T] {
+	return func(st *State) (T, error) {
+		return v, nil
+	}
+}
+
+// Satisfy returns a parser that consumes and returns one rune satisfying p
+func Satisfy(p func(rune) bool) Parser[rune] {
+	return func(st *State) (rune, error) {
+		if r := st.Peek(); r != strex.EOF && p(r) {
+			return st.Next(), nil
+		}
+		return 0, st.Fail()
+	}
+}
+
+// Rune returns a parser that consumes and returns the rune r
+func Rune(r rune) Parser[rune] {
+	return Label(Satisfy(func(c rune) bool { return c == r }), strconv.Quote(string(r)))
+}
+
+// String returns a parser that consumes and returns s. It consumes nothing
+// if the input does not start with s.
+func String(s string) Parser[string] {
+	return func(st *State) (string, error) {
+		if !strings.HasPrefix(st.Rest(), s) {
+			return "", st.Fail(strconv.Quote(s))
+		}
+		for range s {
+			st.Next()
+		}
+		return s, nil
+	}
+}
+
+// TakeWhile returns a parser that consumes and returns the longest prefix,
+// possibly empty, of runes satisfying p
+func TakeWhile(p func(rune) bool) Parser[string] {
+	return func(st *State) (string, error) {
+		return st.TakeWhile(p), nil
+	}
+}
+
+// TakeWhile1 is like TakeWhile but fails if not even one rune satisfies p
+func TakeWhile1(p func(rune) bool) Parser[string] {
+	return func(st *State) (string, error) {
+		if s := st.TakeWhile(p); s != "" {
+			return s, nil
+		}
+		return "", st.Fail()
+	}
+}
+
+// Eof returns a parser that only succeeds at the end of the input
+func Eof() Parser[struct{}] {
+	return func(st *State) (struct{}, error) {
+		if !st.Done() {
+			return struct{}{}, st.Fail("end of input")
+		}
+		return struct{}{}, nil
+	}
+}
+
+// Label returns a parser that behaves like p, but reports name instead of
+// whatever p expected when p fails without consuming input
+func Label[T any](p Parser[T], name string) Parser[T] {
+	return func(st *State) (T, error) {
+		start, hint := st.Pos(), st.hint
+		v, err := p(st)
+		if e, ok := err.(*ParseError); ok && st.Pos().Offset == start.Offset {
+			l := *e
+			l.Expected = []string{name}
+			if hint != nil && hint.Pos.Offset == start.Offset {
+				l.Expected = union(hint.Expected, l.Expected)
+			}
+			return v, &l
+		}
+		return v, err
+	}
+}
+
+// Try returns a parser that behaves like p, but rewinds the input when p
+// fails so that the failure does not count as having consumed input
+func Try[T any](p Parser[T]) Parser[T] {
+	return func(st *State) (T, error) {
+		start := st.Mark()
+		v, err := p(st)
+		if err != nil {
+			st.Reset(start)
+		}
+		return v, err
+	}
+}
+
+// Choice returns a parser that tries each of ps in turn and returns the
+// result of the first one that succeeds. It stops at the first parser that
+// fails after consuming input.
+func Choice[T any](ps ...Parser[T]) Parser[T] {
+	return func(st *State) (T, error) {
+		var zero T
+		start := st.Pos()
+		var perr *ParseError
+		for _, p := range ps {
+			v, err := p(st)
+			if err == nil {
+				return v, nil
+			}
+			if st.Pos().Offset != start.Offset {
+				return zero, err
+			}
+			st.remember(err)
+			if e, ok := err.(*ParseError); ok {
+				perr = merge(perr, e)
+			} else {
+				return zero, err
+			}
+		}
+		if perr == nil {
+			return zero, st.Fail()
+		}
+		return zero, perr
+	}
+}
+
+// Optional returns a parser that runs p and returns def if p fails without
+// consuming input
+func Optional[T any](p Parser[T], def T) Parser[T] {
+	return Choice(p, Pure(def))
+}
+
+// Many returns a parser that applies p zero or more times. It panics if p
+// succeeds without consuming input, as that would loop forever.
+func Many[T any](p Parser[T]) Parser[[]T] {
+	return func(st *State) ([]T, error) {
+		vs := []T{}
+		for {
+			start := st.Pos()
+			v, err := p(st)
+			if err != nil {
+				if st.Pos().Offset != start.Offset {
+					return nil, err
+				}
+				st.remember(err)
+				return vs, nil
+			}
+			if st.Pos().Offset == start.Offset {
+				panic("parse: Many applied to a parser that accepts an empty string")
+			}
+			vs = append(vs, v)
+		}
+	}
+}
+
+// Many1 is like Many but requires p to succeed at least once
+func Many1[T any](p Parser[T]) Parser[[]T] {
+	return Bind(p, func(v T) Parser[[]T] {
+		return Map(Many(p), func(vs []T) []T { return append([]T{v}, vs...) })
+	})
+}
+
+// SepBy returns a parser for zero or more occurrences of p separated by sep
+func SepBy[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return Optional(SepBy1(p, sep), []T{})
+}
+
+// SepBy1 is like SepBy but requires at least one occurrence of p
+func SepBy1[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return Bind(p, func(v T) Parser[[]T] {
+		return Map(Many(Then(sep, p)), func(vs []T) []T { return append([]T{v}, vs...) })
+	})
+}
+
+// Map returns a parser that applies f to the result of p
+func Map[T, U any](p Parser[T], f func(T) U) Parser[U] {
+	return func(st *State) (U, error) {
+		v, err := p(st)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return f(v), nil
+	}
+}
+
+// Bind returns a parser that runs p and then the parser f returns for the
+// result of p
+func Bind[T, U any](p Parser[T], f func(T) Parser[U]) Parser[U] {
+	return func(st *State) (U, error) {
+		v, err := p(st)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return f(v)(st)
+	}
+}
+
+// Then returns a parser that runs p and then q, returning the result of q
+func Then[T, U any](p Parser[T], q Parser[U]) Parser[U] {
+	return Bind(p, func(T) Parser[U] { return q })
+}
+
+// Skip returns a parser that runs p and then q, returning the result of p
+func Skip[T, U any](p Parser[T], q Parser[U]) Parser[T] {
+	return Bind(p, func(v T) Parser[T] { return Map(q, func(U) T { return v }) })
+}
+
+// Between returns a parser that runs open, p and close in turn, returning
+// the result of p
+func Between[O, C, T any](open Parser[O], close Parser[C], p Parser[T]) Parser[T] {
+	return Then(open, Skip(p, close))
+}
+
+// Lazy returns a parser that calls f the first time it runs. It allows
+// recursive grammars to refer to parsers that are not built yet. The result
+// is safe to run from several goroutines at once.
+func Lazy[T any](f func() Parser[T]) Parser[T] {
+	p := sync.OnceValue(f)
+	return func(st *State) (T, error) {
+		return p()(st)
+	}
+}
@@ -0,0 +1,124 @@
+package parse
+
+import (
+	"github.com/bmizerany/assert"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+)
+
+var digit Parser[rune] = Label(Satisfy(unicode.IsDigit), "digit")
+
+var number Parser[int] = Map(Label(TakeWhile1(unicode.IsDigit), "number"), func(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+})
+
+var spaces Parser[string] = TakeWhile(unicode.IsSpace)
+
+func lexeme[T any](p Parser[T]) Parser[T] {
+	return Skip(p, spaces)
+}
+
+var list Parser[[]int] = Between(lexeme(Rune('[')), lexeme(Rune(']')), SepBy(lexeme(number), lexeme(Rune(','))))
+
+// --------------------- PARSE ------------------------
+func TestParseList(t *testing.T) {
+	v, err := Parse(Skip(list, Eof()), "[1, 22 ,333]")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, []int{1, 22, 333})
+
+	v, err = Parse(Skip(list, Eof()), "[ ]")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, []int{})
+}
+
+func TestParseErrorExpected(t *testing.T) {
+	_, err := Parse(Skip(list, Eof()), "[1, 2 x")
+	assert.NotEqual(t, err, nil)
+	var e *ParseError = err.(*ParseError)
+	assert.Equal(t, e.Pos.Column, 7)
+	assert.Equal(t, e.Unexpected, `"x"`)
+	assert.Equal(t, e.Expected, []string{`","`, `"]"`})
+	assert.Equal(t, err.Error(), `1:7: unexpected "x", expecting "," or "]"`)
+}
+
+func TestParseErrorAtEnd(t *testing.T) {
+	_, err := Parse(Skip(Many(digit), Eof()), "12a")
+	assert.Equal(t, err.Error(), `1:3: unexpected "a", expecting digit or end of input`)
+
+	_, err = Parse(list, "[1,")
+	assert.Equal(t, err.Error(), `1:4: unexpected end of input, expecting number`)
+}
+
+func TestParseMany1(t *testing.T) {
+	v, err := Parse(Many1(digit), "123")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(v), "123")
+
+	_, err = Parse(Many1(digit), "abc")
+	assert.Equal(t, err.Error(), `1:1: unexpected "a", expecting digit`)
+}
+
+func TestParseChoiceCommits(t *testing.T) {
+	var p Parser[string] = Choice(String("let"), String("lambda"))
+	v, err := Parse(p, "lambda")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, "lambda")
+
+	//"le" is consumed by the first alternative, so the second is not tried
+	var q Parser[string] = Choice(Then(Rune('l'), String("et")), String("lambda"))
+	_, err = Parse(q, "lambda")
+	assert.Equal(t, err.Error(), `1:2: unexpected "a", expecting "et"`)
+
+	var r Parser[string] = Choice(Try(Then(Rune('l'), String("et"))), String("lambda"))
+	v, err = Parse(r, "lambda")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, "lambda")
+}
+
+func TestParseConfig(t *testing.T) {
+	var ident Parser[string] = lexeme(Label(TakeWhile1(unicode.IsLetter), "identifier"))
+	var value Parser[string] = lexeme(Label(TakeWhile1(func(r rune) bool { return r != ';' && !unicode.IsSpace(r) }), "value"))
+	type pair struct{ key, value string }
+	var entry Parser[pair] = Bind(ident, func(k string) Parser[pair] {
+		return Then(lexeme(Rune('=')), Map(value, func(v string) pair { return pair{k, v} }))
+	})
+	var config Parser[[]pair] = Skip(Then(spaces, SepBy(entry, lexeme(Rune(';')))), Eof())
+
+	v, err := Parse(config, " name = strex ;\nversion=2")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, []pair{{"name", "strex"}, {"version", "2"}})
+
+	_, err = Parse(config, "name = strex;\nversion 2")
+	assert.Equal(t, err.(*ParseError).Pos.Line, 2)
+	assert.T(t, strings.HasSuffix(err.Error(), `expecting "="`), err)
+}
+
+func TestParseLazy(t *testing.T) {
+	//nested parentheses, returning the depth
+	var nested Parser[int]
+	nested = Optional(Between(Rune('('), Rune(')'), Map(Lazy(func() Parser[int] { return nested }), func(n int) int { return n + 1 })), 0)
+
+	//the first runs may race to resolve the lazy parser
+	var wg sync.WaitGroup
+	depths, errs := make([]int, 4), make([]error, 4)
+	for i := range depths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			depths[i], errs[i] = Parse(Skip(nested, Eof()), "(())")
+		}()
+	}
+	wg.Wait()
+	for i := range depths {
+		assert.Equal(t, errs[i], nil)
+		assert.Equal(t, depths[i], 2)
+	}
+
+	v, err := Parse(Skip(nested, Eof()), "((()))")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, 3)
+}
@@ -0,0 +1,39 @@
+package strex
+
+import (
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// CommonPrefix returns the longest string that is a prefix of every string
+// in ss. Unlike a byte-wise comparison it never ends inside a rune.
+func CommonPrefix(ss []string) string {
+	return runes.CommonPrefix(runes.Equal, ss)
+}
+
+// CommonSuffix returns the longest string that is a suffix of every string
+// in ss. It never starts inside a rune.
+func CommonSuffix(ss []string) string {
+	return runes.CommonSuffix(runes.Equal, ss)
+}
+
+// CommonPrefixFold is like CommonPrefix but compares runes under Unicode
+// simple case folding. The result is the prefix as spelled in ss[0].
+func CommonPrefixFold(ss []string) string {
+	return runes.CommonPrefix(runes.EqualFold, ss)
+}
+
+// CommonSuffixFold is like CommonSuffix but compares runes under Unicode
+// simple case folding. The result is the suffix as spelled in ss[0].
+func CommonSuffixFold(ss []string) string {
+	return runes.CommonSuffix(runes.EqualFold, ss)
+}
+
+// StripCommonPrefix returns the strings in ss with their CommonPrefix removed
+func StripCommonPrefix(ss []string) []string {
+	n := len(CommonPrefix(ss))
+	t := make([]string, len(ss))
+	for i, s := range ss {
+		t[i] = s[n:]
+	}
+	return t
+}
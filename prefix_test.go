@@ -0,0 +1,56 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"testing"
+)
+
+// --------------------- COMMONPREFIX ------------------------
+func TestCommonPrefix(t *testing.T) {
+	var input []string = []string{"interstellar", "internet", "interval"}
+	var expected string = "inter"
+	var actual string = CommonPrefix(input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestCommonPrefixDoesNotSplitRunes(t *testing.T) {
+	//é and è share their first byte
+	var input []string = []string{"café", "cafè"}
+	var expected string = "caf"
+	var actual string = CommonPrefix(input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestCommonPrefixWithEmpty(t *testing.T) {
+	assert.Equal(t, CommonPrefix(nil), "")
+	assert.Equal(t, CommonPrefix([]string{"abc"}), "abc")
+	assert.Equal(t, CommonPrefix([]string{"abc", ""}), "")
+	assert.Equal(t, CommonPrefix([]string{"\xff1", "\xfe1"}), "")
+}
+
+func TestCommonPrefixFold(t *testing.T) {
+	var input []string = []string{"Straße", "STRASSE", "strict"}
+	assert.Equal(t, CommonPrefixFold(input), "Str")
+	//U+212A KELVIN SIGN folds to k but is three bytes long
+	assert.Equal(t, CommonPrefixFold([]string{"kelvin", "\u212Aelvin"}), "kelvin")
+}
+
+// --------------------- COMMONSUFFIX ------------------------
+func TestCommonSuffix(t *testing.T) {
+	var input []string = []string{"/usr/lib/libc.so", "/lib/libc.so", "libc.so"}
+	assert.Equal(t, CommonSuffix(input), "libc.so")
+	assert.Equal(t, CommonSuffix([]string{"né", "nè"}), "")
+	assert.Equal(t, CommonSuffix([]string{"日本語", "英語"}), "語")
+}
+
+func TestCommonSuffixFold(t *testing.T) {
+	assert.Equal(t, CommonSuffixFold([]string{"report.PDF", "scan.pdf"}), ".PDF")
+}
+
+// --------------------- STRIPCOMMONPREFIX ------------------------
+func TestStripCommonPrefix(t *testing.T) {
+	var input []string = []string{"src/strex/a.go", "src/strex/b.go", "src/str.go"}
+	var expected []string = []string{"ex/a.go", "ex/b.go", ".go"}
+	var actual []string = StripCommonPrefix(input)
+	assert.Equal(t, actual, expected)
+}
@@ -0,0 +1,361 @@
+package strex
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLeaf is the largest number of bytes a rope leaf holds. Larger strings
+// are cut into several leaves, smaller neighbouring leaves are merged.
+const maxLeaf = 512
+
+// Rope is an immutable string made of a balanced tree of smaller strings.
+//
+// Inserting into or deleting from the middle of a Go string means copying the
+// whole string. A Rope shares everything but the O(log n) nodes on the path to
+// the edit, which makes it suitable for very large documents that change
+// often. All indices are rune indices. The zero value is an empty Rope.
+type Rope struct {
+	root *ropeNode
+}
+
+type ropeNode struct {
+	left, right *ropeNode
+	leaf        string
+	runes       int
+	bytes       int
+	height      int
+}
+
+func newLeaf(s string) *ropeNode {
+	if s == "" {
+		return nil
+	}
+	return &ropeNode{leaf: s, runes: utf8.RuneCountInString(s), bytes: len(s), height: 1}
+}
+
+func newNode(l, r *ropeNode) *ropeNode {
+	return &ropeNode{
+		left:   l,
+		right:  r,
+		runes:  l.runes + r.runes,
+		bytes:  l.bytes + r.bytes,
+		height: max(l.height, r.height) + 1,
+	}
+}
+
+func (n *ropeNode) isLeaf() bool {
+	return n.left == nil
+}
+
+func height(n *ropeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// balance restores the AVL invariant of a node whose children differ in
+// height by at most two
+func balance(l, r *ropeNode) *ropeNode {
+	switch {
+	case l.height > r.height+1:
+		if height(l.left) >= height(l.right) {
+			return newNode(l.left, newNode(l.right, r))
+		}
+		return newNode(newNode(l.left, l.right.left), newNode(l.right.right, r))
+	case r.height > l.height+1:
+		if height(r.right) >= height(r.left) {
+			return newNode(newNode(l, r.left), r.right)
+		}
+		return newNode(newNode(l, r.left.left), newNode(r.left.right, r.right))
+	}
+	return newNode(l, r)
+}
+
+// join concatenates two balanced trees of arbitrary heights
+func join(l, r *ropeNode) *ropeNode {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.isLeaf() && r.isLeaf() && l.bytes+r.bytes <= maxLeaf:
+		return newLeaf(l.leaf + r.leaf)
+	case l.height > r.height+1:
+		return balance(l.left, join(l.right, r))
+	case r.height > l.height+1:
+		return balance(join(l, r.left), r.right)
+	}
+	return newNode(l, r)
+}
+
+// split cuts the tree after the first i runes
+func split(n *ropeNode, i int) (*ropeNode, *ropeNode) {
+	switch {
+	case n == nil:
+		return nil, nil
+	case i <= 0:
+		return nil, n
+	case i >= n.runes:
+		return n, nil
+	case n.isLeaf():
+		off := len(Take(i, n.leaf))
+		return newLeaf(n.leaf[:off]), newLeaf(n.leaf[off:])
+	case i <= n.left.runes:
+		a, b := split(n.left, i)
+		return a, join(b, n.right)
+	}
+	a, b := split(n.right, i-n.left.runes)
+	return join(n.left, a), b
+}
+
+// build returns a balanced tree over the given leaves
+func build(leaves []string) *ropeNode {
+	switch len(leaves) {
+	case 0:
+		return nil
+	case 1:
+		return newLeaf(leaves[0])
+	}
+	m := len(leaves) / 2
+	return newNode(build(leaves[:m]), build(leaves[m:]))
+}
+
+// chunk cuts s into pieces of at most maxLeaf bytes without splitting a rune
+func chunk(s string) []string {
+	leaves := make([]string, 0, len(s)/maxLeaf+1)
+	for len(s) > maxLeaf {
+		i := maxLeaf
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		if i == 0 {
+			i = maxLeaf
+		}
+		leaves = append(leaves, s[:i])
+		s = s[i:]
+	}
+	if s != "" {
+		leaves = append(leaves, s)
+	}
+	return leaves
+}
+
+// walk calls f on every leaf from left to right until f returns false
+func (n *ropeNode) walk(f func(string) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.isLeaf() {
+		return f(n.leaf)
+	}
+	return n.left.walk(f) && n.right.walk(f)
+}
+
+// NewRope returns a Rope holding s
+func NewRope(s string) Rope {
+	return Rope{build(chunk(s))}
+}
+
+// Len returns the number of runes in r
+func (r Rope) Len() int {
+	if r.root == nil {
+		return 0
+	}
+	return r.root.runes
+}
+
+// ByteLen returns the number of bytes in r
+func (r Rope) ByteLen() int {
+	if r.root == nil {
+		return 0
+	}
+	return r.root.bytes
+}
+
+// IsEmpty tests whether r is empty
+func (r Rope) IsEmpty() bool {
+	return r.root == nil
+}
+
+// String returns the contents of r as a plain string
+func (r Rope) String() string {
+	if r.root != nil && r.root.isLeaf() {
+		return r.root.leaf
+	}
+	var b strings.Builder
+	b.Grow(r.ByteLen())
+	r.root.walk(func(s string) bool {
+		b.WriteString(s)
+		return true
+	})
+	return b.String()
+}
+
+// Concat returns the concatenation of r and o
+func (r Rope) Concat(o Rope) Rope {
+	return Rope{join(r.root, o.root)}
+}
+
+// Split returns the first i runes of r and the remainder
+func (r Rope) Split(i int) (Rope, Rope) {
+	a, b := split(r.root, i)
+	return Rope{a}, Rope{b}
+}
+
+// Insert returns r with s inserted before the i-th rune
+func (r Rope) Insert(i int, s string) Rope {
+	a, b := split(r.root, i)
+	return Rope{join(join(a, NewRope(s).root), b)}
+}
+
+// Delete returns r without the runes from index i up to but not including
+// index j. The indices are clamped to the bounds of r, and r is returned
+// unchanged if j <= i.
+func (r Rope) Delete(i, j int) Rope {
+	i, j = min(max(i, 0), r.Len()), min(max(j, 0), r.Len())
+	if j <= i {
+		return r
+	}
+	a, rest := split(r.root, i)
+	_, b := split(rest, j-i)
+	return Rope{join(a, b)}
+}
+
+// Take is the Rope version of Take
+func (r Rope) Take(n int) Rope {
+	a, _ := split(r.root, n)
+	return Rope{a}
+}
+
+// Drop is the Rope version of Drop
+func (r Rope) Drop(n int) Rope {
+	_, b := split(r.root, n)
+	return Rope{b}
+}
+
+// Head returns the first rune of r which must be non-empty
+func (r Rope) Head() rune {
+	if r.root == nil {
+		panic("empty list")
+	}
+	n := r.root
+	for !n.isLeaf() {
+		n = n.left
+	}
+	return Head(n.leaf)
+}
+
+// Last returns the last rune of r which must be non-empty
+func (r Rope) Last() rune {
+	if r.root == nil {
+		panic("empty list")
+	}
+	n := r.root
+	for !n.isLeaf() {
+		n = n.right
+	}
+	return Last(n.leaf)
+}
+
+// span returns the number of leading runes of r that satisfy p
+func (r Rope) span(p func(rune) bool) int {
+	i := 0
+	r.root.walk(func(s string) bool {
+		for _, c := range s {
+			if !p(c) {
+				return false
+			}
+			i++
+		}
+		return true
+	})
+	return i
+}
+
+// TakeWhile is the Rope version of TakeWhile
+func (r Rope) TakeWhile(p func(rune) bool) Rope {
+	return r.Take(r.span(p))
+}
+
+// DropWhile is the Rope version of DropWhile
+func (r Rope) DropWhile(p func(rune) bool) Rope {
+	return r.Drop(r.span(p))
+}
+
+// Reverse is the Rope version of Reverse
+func (r Rope) Reverse() Rope {
+	var rev func(n *ropeNode) *ropeNode
+	rev = func(n *ropeNode) *ropeNode {
+		if n == nil {
+			return nil
+		}
+		if n.isLeaf() {
+			return newLeaf(Reverse(n.leaf))
+		}
+		return newNode(rev(n.right), rev(n.left))
+	}
+	return Rope{rev(r.root)}
+}
+
+// Filter is the Rope version of Filter
+func (r Rope) Filter(p func(rune) bool) Rope {
+	var leaves []string
+	r.root.walk(func(s string) bool {
+		if t := Filter(p, s); t != "" {
+			if n := len(leaves); n > 0 && len(leaves[n-1])+len(t) <= maxLeaf {
+				leaves[n-1] += t
+			} else {
+				leaves = append(leaves, t)
+			}
+		}
+		return true
+	})
+	return Rope{build(leaves)}
+}
+
+// Reader returns an io.Reader over the contents of r
+func (r Rope) Reader() io.Reader {
+	rr := &ropeReader{}
+	rr.push(r.root)
+	return rr
+}
+
+type ropeReader struct {
+	stack []*ropeNode
+	cur   string
+}
+
+// push descends the left spine of n, remembering the right children
+func (rr *ropeReader) push(n *ropeNode) {
+	for n != nil && !n.isLeaf() {
+		rr.stack = append(rr.stack, n.right)
+		n = n.left
+	}
+	if n != nil {
+		rr.stack = append(rr.stack, n)
+	}
+}
+
+func (rr *ropeReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for rr.cur == "" {
+		if len(rr.stack) == 0 {
+			return 0, io.EOF
+		}
+		n := rr.stack[len(rr.stack)-1]
+		rr.stack = rr.stack[:len(rr.stack)-1]
+		if n.isLeaf() {
+			rr.cur = n.leaf
+		} else {
+			rr.push(n)
+		}
+	}
+	k := copy(p, rr.cur)
+	rr.cur = rr.cur[k:]
+	return k, nil
+}
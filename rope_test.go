@@ -0,0 +1,117 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// checkRope verifies the cached sizes and the balance of every node
+func checkRope(t *testing.T, n *ropeNode) {
+	if n == nil || n.isLeaf() {
+		return
+	}
+	checkRope(t, n.left)
+	checkRope(t, n.right)
+	d := n.left.height - n.right.height
+	assert.T(t, -1 <= d && d <= 1, "unbalanced node")
+	assert.Equal(t, n.runes, n.left.runes+n.right.runes)
+	assert.Equal(t, n.bytes, n.left.bytes+n.right.bytes)
+}
+
+// --------------------- ROPE ------------------------
+func TestRope(t *testing.T) {
+	var rope Rope = NewRope("hello world")
+	assert.Equal(t, rope.Len(), 11)
+	assert.Equal(t, rope.Insert(5, ",").String(), "hello, world")
+	assert.Equal(t, rope.Delete(5, 11).String(), "hello")
+	assert.Equal(t, NewRope("abcdefgh").Delete(-2, 3).String(), "defgh")
+	assert.Equal(t, NewRope("abcdefgh").Delete(5, 20).String(), "abcde")
+	assert.Equal(t, rope.Take(4).String(), "hell")
+	assert.Equal(t, rope.Drop(6).String(), "world")
+	assert.Equal(t, rope.Reverse().String(), "dlrow olleh")
+	assert.Equal(t, rope.String(), "hello world")
+}
+
+func TestRopeWithEmpty(t *testing.T) {
+	var rope Rope
+	assert.Equal(t, rope.Len(), 0)
+	assert.Equal(t, rope.IsEmpty(), true)
+	assert.Equal(t, rope.String(), "")
+	assert.Equal(t, rope.Insert(3, "abc").String(), "abc")
+	assert.Equal(t, rope.Reverse().String(), "")
+}
+
+func TestRopeRandomEdits(t *testing.T) {
+	var alphabet []rune = []rune("abcxyz éü世界😀\n")
+	rnd := rand.New(rand.NewSource(1))
+	randomString := func(n int) string {
+		rs := make([]rune, n)
+		for i := range rs {
+			rs[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(rs)
+	}
+
+	var expected string = randomString(3000)
+	var rope Rope = NewRope(expected)
+	for k := 0; k < 200; k++ {
+		n := utf8.RuneCountInString(expected)
+		i := rnd.Intn(n + 1)
+		switch rnd.Intn(3) {
+		case 0:
+			s := randomString(rnd.Intn(700))
+			expected = Take(i, expected) + s + Drop(i, expected)
+			rope = rope.Insert(i, s)
+		case 1:
+			j := i + rnd.Intn(300)
+			expected = Take(i, expected) + Drop(j, expected)
+			rope = rope.Delete(i, j)
+		case 2:
+			a, b := rope.Split(i)
+			assert.Equal(t, a.String(), Take(i, expected))
+			assert.Equal(t, b.String(), Drop(i, expected))
+			rope = b.Concat(a)
+			expected = Drop(i, expected) + Take(i, expected)
+		}
+		checkRope(t, rope.root)
+		assert.Equal(t, rope.Len(), utf8.RuneCountInString(expected))
+		assert.Equal(t, rope.ByteLen(), len(expected))
+	}
+	assert.Equal(t, rope.String(), expected)
+	assert.Equal(t, rope.Head(), Head(expected))
+	assert.Equal(t, rope.Last(), Last(expected))
+	assert.Equal(t, rope.Reverse().String(), Reverse(expected))
+	assert.Equal(t, rope.Filter(unicode.IsLetter).String(), Filter(unicode.IsLetter, expected))
+	assert.Equal(t, rope.TakeWhile(unicode.IsPrint).String(), TakeWhile(unicode.IsPrint, expected))
+	assert.Equal(t, rope.DropWhile(unicode.IsPrint).String(), DropWhile(unicode.IsPrint, expected))
+
+	b, err := io.ReadAll(rope.Reader())
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(b), expected)
+}
+
+func TestRopeReader(t *testing.T) {
+	var input string = strings.Repeat("日本語", 1000)
+	b, err := io.ReadAll(NewRope(input).Reader())
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(b), input)
+}
+
+func TestRopeHeadWithEmpty(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log("Exception was thrown successfully\n")
+		} else {
+			FailWithLog(t, "No exception was thrown!")
+		}
+	}()
+
+	//should throw panic for empty
+	Rope{}.Head()
+	t.Fail()
+}
@@ -46,14 +46,63 @@ func Tail(s string) string {
 //	return x + Take(n-1, xs)
 //}
 
-//Take returns the n rune prefix of s or s itself if n > len([]rune(s))
-func Take(n int, s string) string {
-	for i := range s {
+//asciiPrefix returns the length of the longest prefix of s that is made of
+//ASCII characters only, checking 8 bytes at a time
+func asciiPrefix(s string) int {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		w := uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+			uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+		if w&0x8080808080808080 != 0 {
+			break
+		}
+	}
+	for ; i < len(s) && s[i] < utf8.RuneSelf; i++ {
+	}
+	return i
+}
+
+//runeOffset returns the byte offset of the n-th rune of s, or -1 if s has
+//fewer than n runes. n must not be negative.
+func runeOffset(n int, s string) int {
+	a := asciiPrefix(s[:min(n, len(s))])
+	if a == n {
+		return n
+	}
+	n -= a
+	for i := range s[a:] {
 		if n <= 0 {
-			return s[0:i]
+			return a + i
 		}
 		n--
 	}
+	if n == 0 {
+		return len(s)
+	}
+	return -1
+}
+
+//Removed. Decoded every rune even when the prefix is plain ASCII
+//BenchmarkCorpusTake/ASCII	 1233555	       982.2 ns/op
+//BenchmarkCorpusTake/ASCII	 5344489	       211.7 ns/op - ASCII fast path
+//func Take(n int, s string) string {
+//	for i := range s {
+//		if n <= 0 {
+//			return s[0:i]
+//		}
+//		n--
+//	}
+//	return s
+//}
+
+//Take returns the n rune prefix of s or s itself if n > len([]rune(s))
+func Take(n int, s string) string {
+	if n <= 0 {
+		return ""
+	}
+	if i := runeOffset(n, s); i >= 0 {
+		return s[:i]
+	}
 	return s
 }
 
@@ -71,11 +120,11 @@ func Take(n int, s string) string {
 
 //Drop returns the suffix of s after the first n runes, or "" if n > len([]rune(s))
 func Drop(n int, s string) string {
-	for i := range s {
-		if n <= 0 {
-			return s[i:]
-		}
-		n--
+	if n <= 0 {
+		return s
+	}
+	if i := runeOffset(n, s); i >= 0 {
+		return s[i:]
 	}
 	return ""
 }
@@ -145,18 +194,7 @@ func DropWhile(p func(rune) bool, s string) string {
 
 //Reverse returns the string s in reverse order
 func Reverse(s string) string {
-	t := make([]byte, 0, len(s))
-	for len(s) > 0 {
-		n := 1
-		if s[len(s)-1] > 0x7f {
-			_, n = utf8.DecodeLastRuneInString(s)
-			t = append(t, s[len(s)-n:]...)
-		} else {
-			t = append(t, s[len(s)-1])
-		}
-		s = s[0 : len(s)-n]
-	}
-	return string(t)
+	return string(AppendReverse(make([]byte, 0, len(s)), s))
 }
 
 //func Filter(p func(rune) bool, s string) string {
@@ -205,17 +243,7 @@ func Group(s string) []string {
 
 //GroupBy is the non-overloaded version of Group.
 func GroupBy(p func(rune, rune) bool, s string) []string {
-	ss := []string{}
-	for len(s) > 0 {
-		r0, n := utf8.DecodeRuneInString(s)
-		t := TakeWhile(func(r rune) bool {
-			return p(r0, r)
-		}, s[n:])
-		n += len(t)
-		ss = append(ss, s[0:n])
-		s = s[n:]
-	}
-	return ss
+	return GroupByInto([]string{}, p, s)
 }
 
 //Replacing due to terrible performance issues
@@ -261,10 +289,25 @@ func Last(s string) rune {
 		panic("empty list")
 	}
 
+	if c := s[len(s)-1]; c < utf8.RuneSelf {
+		return rune(c)
+	}
 	r, _ := utf8.DecodeLastRuneInString(s)
 	return r
 }
 
+//Removed. Assumed every rune is as wide as the first one, so
+//Init("héllo") returned "hél"
+//func Init(s string) string {
+//	if s == "" {
+//		panic("empty list")
+//	}
+//
+//	_, sz := utf8.DecodeRuneInString(s)
+//	c := utf8.RuneCountInString(s)
+//	return s[:(sz*c)-sz]
+//}
+
 //Init returns all the elements of s except the last one. The string must 
 //be non-empty.
 func Init(s string) string {
@@ -272,9 +315,17 @@ func Init(s string) string {
 		panic("empty list")
 	}
 
-	_, sz := utf8.DecodeRuneInString(s)
-	c := utf8.RuneCountInString(s)
-	return s[:(sz*c)-sz]
+	if s[len(s)-1] < utf8.RuneSelf {
+		return s[:len(s)-1]
+	}
+	_, sz := utf8.DecodeLastRuneInString(s)
+	return s[:len(s)-sz]
+}
+
+//Len returns the number of runes in s
+func Len(s string) int {
+	a := asciiPrefix(s)
+	return a + utf8.RuneCountInString(s[a:])
 }
 
 //IsEmpty tests whether the string s is empty
@@ -292,3 +343,26 @@ func All(p func(rune) bool, s string) bool {
 	}
 	return true
 }
+
+//Any applied to a predicate p and a string s, determines if any element of
+//s satisfies p
+func Any(p func(rune) bool, s string) bool {
+	for _, r := range s {
+		if p(r) {
+			return true
+		}
+	}
+	return false
+}
+
+//Count applied to a predicate p and a string s, returns the number of
+//elements of s that satisfy p
+func Count(p func(rune) bool, s string) int {
+	n := 0
+	for _, r := range s {
+		if p(r) {
+			n++
+		}
+	}
+	return n
+}
@@ -147,6 +147,14 @@ func TestTakeWithStringLength(t *testing.T) {
 	assert.Equal(t, actual, expected)
 }
 
+func TestTakeMixedWidth(t *testing.T) {
+	var input string = "abcdefghé世😀xyz"
+	for n, expected := range []string{"", "a", "abcdefgh", "abcdefghé", "abcdefghé世", "abcdefghé世😀", "abcdefghé世😀xyz"} {
+		k := []int{0, 1, 8, 9, 10, 11, 14}[n]
+		assert.Equal(t, Take(k, input), expected)
+	}
+}
+
 // --------------------- DROP ------------------------
 func TestDrop(t *testing.T) {
 	var input string = "abcdef"
@@ -204,6 +212,14 @@ func TestDropWithBelowZero(t *testing.T) {
 	assert.Equal(t, actual, expected)
 }
 
+func TestDropMixedWidth(t *testing.T) {
+	var input string = "abcdefghé世😀xyz"
+	for n, expected := range []string{input, "bcdefghé世😀xyz", "é世😀xyz", "世😀xyz", "😀xyz", "xyz", ""} {
+		k := []int{0, 1, 8, 9, 10, 11, 14}[n]
+		assert.Equal(t, Drop(k, input), expected)
+	}
+}
+
 // --------------------- REVERSE ------------------------
 func TestReverse(t *testing.T) {
 	var input string = "testing"
@@ -492,6 +508,21 @@ func TestInitWithOneChar(t *testing.T) {
 	assert.Equal(t, actual, expected)
 }
 
+func TestInitMixedWidth(t *testing.T) {
+	assert.Equal(t, Init("héllo"), "héll")
+	assert.Equal(t, Init("hellé"), "hell")
+	assert.Equal(t, Init("😀a世"), "😀a")
+}
+
+// --------------------- LEN ------------------------
+func TestLen(t *testing.T) {
+	assert.Equal(t, Len(""), 0)
+	assert.Equal(t, Len("daniel"), 6)
+	assert.Equal(t, Len("abcdefghijklmnop"), 16)
+	assert.Equal(t, Len("abcdefghé世😀xyz"), 14)
+	assert.Equal(t, Len("世界"), 2)
+}
+
 // --------------------- ISEMPTY ------------------------
 func TestIsEmptyTrue(t *testing.T) {
 	var input string = ""
@@ -547,6 +578,28 @@ func TestAllFalse(t *testing.T) {
 	assert.Equal(t, actual, expected)
 }
 
+// --------------------- ANY ------------------------
+func TestAny(t *testing.T) {
+	var isUppercase func(rune) bool = func(r rune) bool {
+		return r >= 65 && r <= 90
+	}
+
+	assert.Equal(t, Any(isUppercase, "aaaA"), true)
+	assert.Equal(t, Any(isUppercase, "aaaa"), false)
+	assert.Equal(t, Any(isUppercase, ""), false)
+}
+
+// --------------------- COUNT ------------------------
+func TestCount(t *testing.T) {
+	var isUppercase func(rune) bool = func(r rune) bool {
+		return r >= 65 && r <= 90
+	}
+
+	assert.Equal(t, Count(isUppercase, "AbCdéF"), 3)
+	assert.Equal(t, Count(isUppercase, "abc"), 0)
+	assert.Equal(t, Count(isUppercase, ""), 0)
+}
+
 // --------------------- TAKEWHILE ------------------------
 func TestTakeWhile(t *testing.T) {
 	var isA func(rune)bool = func(r rune)bool { return r == 'a' }
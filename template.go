@@ -0,0 +1,122 @@
+package strex
+
+import (
+	"errors"
+	"unicode"
+)
+
+var errEmpty = errors.New("strex: empty string")
+
+// namedPredicates are the predicates that Apply knows by name, which is that
+// of the unicode package function without its "Is"
+var namedPredicates = map[string]func(rune) bool{
+	"control": unicode.IsControl,
+	"digit":   unicode.IsDigit,
+	"graphic": unicode.IsGraphic,
+	"letter":  unicode.IsLetter,
+	"lower":   unicode.IsLower,
+	"mark":    unicode.IsMark,
+	"number":  unicode.IsNumber,
+	"print":   unicode.IsPrint,
+	"punct":   unicode.IsPunct,
+	"space":   unicode.IsSpace,
+	"symbol":  unicode.IsSymbol,
+	"title":   unicode.IsTitle,
+	"upper":   unicode.IsUpper,
+}
+
+// templatePredicates are the predicates offered to templates by FuncMap
+var templatePredicates = map[string]func(rune) bool{
+	"isControl": namedPredicates["control"],
+	"isDigit":   namedPredicates["digit"],
+	"isGraphic": namedPredicates["graphic"],
+	"isLetter":  namedPredicates["letter"],
+	"isLower":   namedPredicates["lower"],
+	"isMark":    namedPredicates["mark"],
+	"isNumber":  namedPredicates["number"],
+	"isPrint":   namedPredicates["print"],
+	"isPunct":   namedPredicates["punct"],
+	"isSpace":   namedPredicates["space"],
+	"isSymbol":  namedPredicates["symbol"],
+	"isTitle":   namedPredicates["title"],
+	"isUpper":   namedPredicates["upper"],
+}
+
+// FuncMap returns functions for use with the Funcs method of text/template
+// and html/template templates. The result can be assigned to either
+// package's FuncMap type.
+//
+// The functions are named like the strex functions, in lower camel case,
+// and take the string last so that it can be piped in:
+//
+//	{{.Name | take 3}}
+//	{{.Name | padLeft 10 "."}}
+//
+// Runes are returned as one-rune strings rather than as numbers, Span
+// returns a slice of its two results, and head, last, tail and init return
+// an error, which stops the template, instead of panicking on an empty
+// string. Predicates are available by their unicode package names, such as
+// isUpper and isDigit, and can be passed to the functions that need one:
+//
+//	{{.Name | filter isLetter}}
+func FuncMap() map[string]any {
+	m := map[string]any{
+		"head": func(s string) (string, error) {
+			if s == "" {
+				return "", errEmpty
+			}
+			return string(Head(s)), nil
+		},
+		"last": func(s string) (string, error) {
+			if s == "" {
+				return "", errEmpty
+			}
+			return string(Last(s)), nil
+		},
+		"tail": func(s string) (string, error) {
+			if s == "" {
+				return "", errEmpty
+			}
+			return Tail(s), nil
+		},
+		"init": func(s string) (string, error) {
+			if s == "" {
+				return "", errEmpty
+			}
+			return Init(s), nil
+		},
+		"span": func(p func(rune) bool, s string) []string {
+			a, b := Span(p, s)
+			return []string{a, b}
+		},
+		"take":         Take,
+		"drop":         Drop,
+		"takeWhile":    TakeWhile,
+		"dropWhile":    DropWhile,
+		"reverse":      Reverse,
+		"filter":       Filter,
+		"distinct":     Distinct,
+		"distinctFold": DistinctFold,
+		"group":        Group,
+		"groupFold":    GroupFold,
+		"isEmpty":      IsEmpty,
+		"all":          All,
+		"any":          Any,
+		"count":        Count,
+		"runeLen":      Len,
+		"padLeft":      func(n int, fill, s string) string { return PadLeft(s, n, fill) },
+		"padRight":     func(n int, fill, s string) string { return PadRight(s, n, fill) },
+		"center":       func(n int, fill, s string) string { return Center(s, n, fill) },
+		"fitExact":     func(n int, ellipsis, s string) string { return FitExact(s, n, ellipsis) },
+		"fill":         func(width int, s string) string { return Fill(s, width) },
+		"toCamel":      ToCamel,
+		"toPascal":     ToPascal,
+		"toSnake":      ToSnake,
+		"toKebab":      ToKebab,
+		"toTitleWords": ToTitleWords,
+	}
+	for name, p := range templatePredicates {
+		m[name] = func() func(rune) bool { return p }
+	}
+	return m
+}
@@ -0,0 +1,74 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func execute(t *testing.T, text string, data any) (string, error) {
+	tmpl, err := template.New("t").Funcs(FuncMap()).Parse(text)
+	assert.Equal(t, err, nil)
+	var b strings.Builder
+	err = tmpl.Execute(&b, data)
+	return b.String(), err
+}
+
+// --------------------- FUNCMAP ------------------------
+func TestFuncMap(t *testing.T) {
+	cases := [][2]string{
+		{`{{. | take 3}}`, "Hél"},
+		{`{{. | drop 3}}`, "lo World 42"},
+		{`{{reverse .}}`, "24 dlroW olléH"},
+		{`{{distinct .}}`, "Hélo Wrd42"},
+		{`{{distinctFold "aAbB"}}`, "ab"},
+		{`{{group "aabccc"}}`, "[aa b ccc]"},
+		{`{{. | filter isUpper}}`, "HW"},
+		{`{{. | filter isDigit}}`, "42"},
+		{`{{. | takeWhile isLetter}}`, "Héllo"},
+		{`{{. | dropWhile isLetter}}`, " World 42"},
+		{`{{index (span isLetter .) 1}}`, " World 42"},
+		{`{{head .}}{{last .}}`, "H2"},
+		{`{{tail "abc"}}{{init "abc"}}`, "bcab"},
+		{`{{all isLetter .}} {{any isDigit .}}`, "false true"},
+		{`{{count isLetter .}} {{runeLen .}}`, "10 14"},
+		{`{{isEmpty ""}}`, "true"},
+		{`{{"ab" | padLeft 5 "."}}|{{"ab" | padRight 5 ""}}|{{"ab" | center 6 "-"}}`, "...ab|ab   |--ab--"},
+		{`{{. | fitExact 8 "…"}}`, "Héllo W…"},
+		{`{{"aaa bbb" | fill 3}}`, "aaa\nbbb"},
+		{`{{toSnake "parseHTTPServer"}} {{toCamel "user_id"}}`, "parse_http_server userId"},
+	}
+	for _, c := range cases {
+		got, err := execute(t, c[0], "Héllo World 42")
+		assert.Equal(t, err, nil, c[0])
+		assert.Equal(t, got, c[1], c[0])
+	}
+}
+
+func TestFuncMapPredicateNames(t *testing.T) {
+	m := FuncMap()
+	for _, name := range []string{"isControl", "isDigit", "isGraphic", "isLetter", "isLower", "isMark", "isNumber", "isPrint", "isPunct", "isSpace", "isSymbol", "isTitle", "isUpper"} {
+		f, ok := m[name].(func() func(rune) bool)
+		assert.T(t, ok, name)
+		assert.NotEqual(t, f(), nil, name)
+	}
+}
+
+func TestFuncMapErrorsInsteadOfPanicking(t *testing.T) {
+	for _, name := range []string{"head", "last", "tail", "init"} {
+		_, err := execute(t, `{{`+name+` .}}`, "")
+		assert.NotEqual(t, err, nil)
+		assert.T(t, strings.Contains(err.Error(), "strex: empty string"), err)
+	}
+}
+
+func TestFuncMapWithHTMLTemplate(t *testing.T) {
+	tmpl, err := htmltemplate.New("t").Funcs(FuncMap()).Parse(`<b>{{. | take 4}}</b>`)
+	assert.Equal(t, err, nil)
+	var b strings.Builder
+	err = tmpl.Execute(&b, "<i>&x")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, b.String(), "<b>&lt;i&gt;&amp;</b>")
+}
@@ -0,0 +1,159 @@
+package strex
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// textStride is the number of runes between two entries of the index kept by
+// a Text. Locating a rune costs at most textStride decoding steps.
+const textStride = 32
+
+// Text is an immutable string that supports rune indexing in constant time.
+//
+// Take and Drop have to walk s from the start to find the byte offset of the
+// n-th rune. A Text records the byte offset of every textStride-th rune once,
+// so that repeated slicing of the same string does not pay that cost again.
+// Strings that are entirely ASCII need no index at all.
+type Text struct {
+	s     string
+	n     int
+	ascii bool
+	idx   []int
+}
+
+// NewText returns a Text over s
+func NewText(s string) *Text {
+	t := &Text{s: s}
+	i := 0
+	for i < len(s) && s[i] < utf8.RuneSelf {
+		i++
+	}
+	if i == len(s) {
+		t.ascii = true
+		t.n = len(s)
+		return t
+	}
+	t.idx = make([]int, 0, utf8.RuneCountInString(s)/textStride+1)
+	for j := range s {
+		if t.n%textStride == 0 {
+			t.idx = append(t.idx, j)
+		}
+		t.n++
+	}
+	return t
+}
+
+// String returns the underlying string without copying it
+func (t *Text) String() string {
+	return t.s
+}
+
+// Len returns the number of runes in t
+func (t *Text) Len() int {
+	return t.n
+}
+
+// IsASCII reports whether t consists of ASCII characters only
+func (t *Text) IsASCII() bool {
+	return t.ascii
+}
+
+// ByteOffset returns the byte offset in t.String() of the i-th rune. i may
+// equal t.Len(), in which case the length of the string is returned. It panics
+// if i is out of range.
+func (t *Text) ByteOffset(i int) int {
+	if i < 0 || i > t.n {
+		panic("index out of range")
+	}
+	if t.ascii {
+		return i
+	}
+	if i == t.n {
+		return len(t.s)
+	}
+	off := t.idx[i/textStride]
+	for k := i % textStride; k > 0; k-- {
+		if t.s[off] < utf8.RuneSelf {
+			off++
+		} else {
+			_, sz := utf8.DecodeRuneInString(t.s[off:])
+			off += sz
+		}
+	}
+	return off
+}
+
+// RuneIndex returns the index of the rune that contains byte offset b of
+// t.String(). b may equal the length of the string, in which case t.Len() is
+// returned. It panics if b is out of range.
+func (t *Text) RuneIndex(b int) int {
+	if b < 0 || b > len(t.s) {
+		panic("index out of range")
+	}
+	if t.ascii {
+		return b
+	}
+	if b == len(t.s) {
+		return t.n
+	}
+	blk := sort.SearchInts(t.idx, b+1) - 1
+	i := blk * textStride
+	for off := t.idx[blk]; ; i++ {
+		_, sz := utf8.DecodeRuneInString(t.s[off:])
+		if off+sz > b {
+			return i
+		}
+		off += sz
+	}
+}
+
+// At returns the i-th rune of t. It panics if i is out of range.
+func (t *Text) At(i int) rune {
+	if i < 0 || i >= t.n {
+		panic("index out of range")
+	}
+	if t.ascii {
+		return rune(t.s[i])
+	}
+	r, _ := utf8.DecodeRuneInString(t.s[t.ByteOffset(i):])
+	return r
+}
+
+// clamp limits i to the range [0, t.Len()]
+func (t *Text) clamp(i int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > t.n {
+		return t.n
+	}
+	return i
+}
+
+// Slice returns the runes of t from index i up to but not including index j.
+// The indices are clamped to the bounds of t, and an empty string is returned
+// if j <= i.
+func (t *Text) Slice(i, j int) string {
+	i, j = t.clamp(i), t.clamp(j)
+	if j <= i {
+		return ""
+	}
+	return t.s[t.ByteOffset(i):t.ByteOffset(j)]
+}
+
+// Take is the Text version of Take
+func (t *Text) Take(n int) string {
+	return t.s[:t.ByteOffset(t.clamp(n))]
+}
+
+// Drop is the Text version of Drop
+func (t *Text) Drop(n int) string {
+	return t.s[t.ByteOffset(t.clamp(n)):]
+}
+
+// SplitAt returns Take(n) and Drop(n) in a single step
+func (t *Text) SplitAt(n int) (string, string) {
+	off := t.ByteOffset(t.clamp(n))
+	return t.s[:off], t.s[off:]
+}
@@ -0,0 +1,66 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"strings"
+	"testing"
+)
+
+// --------------------- TEXT ------------------------
+func TestTextASCII(t *testing.T) {
+	var text *Text = NewText("golang")
+	assert.Equal(t, text.IsASCII(), true)
+	assert.Equal(t, text.Len(), 6)
+	assert.Equal(t, text.At(2), 'l')
+	assert.Equal(t, text.Slice(1, 4), "ola")
+	assert.Equal(t, text.String(), "golang")
+}
+
+func TestTextMatchesTakeAndDrop(t *testing.T) {
+	var input string = strings.Repeat("héllo, 世界! 😀", 20) + "\xff end"
+	var text *Text = NewText(input)
+	var runes []rune = []rune(input)
+
+	assert.Equal(t, text.IsASCII(), false)
+	assert.Equal(t, text.Len(), len(runes))
+	for n := -1; n <= len(runes)+1; n++ {
+		assert.Equal(t, text.Take(n), Take(n, input))
+		assert.Equal(t, text.Drop(n), Drop(n, input))
+		before, after := text.SplitAt(n)
+		assert.Equal(t, before, Take(n, input))
+		assert.Equal(t, after, Drop(n, input))
+		if 0 <= n && n < len(runes) {
+			assert.Equal(t, text.At(n), runes[n])
+			assert.Equal(t, text.RuneIndex(text.ByteOffset(n)), n)
+		}
+	}
+	assert.Equal(t, text.Slice(7, 9), "世界")
+	assert.Equal(t, text.Slice(9, 7), "")
+}
+
+func TestTextRuneIndexInsideRune(t *testing.T) {
+	var text *Text = NewText("a世b")
+	assert.Equal(t, text.RuneIndex(2), 1)
+	assert.Equal(t, text.RuneIndex(4), 2)
+	assert.Equal(t, text.RuneIndex(5), 3)
+}
+
+func TestTextWithEmpty(t *testing.T) {
+	var text *Text = NewText("")
+	assert.Equal(t, text.Len(), 0)
+	assert.Equal(t, text.Take(3), "")
+	assert.Equal(t, text.Drop(3), "")
+}
+
+func TestTextAtOutOfRange(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log("Exception was thrown successfully\n")
+		} else {
+			FailWithLog(t, "No exception was thrown!")
+		}
+	}()
+
+	NewText("abc").At(3)
+	t.Fail()
+}
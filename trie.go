@@ -0,0 +1,279 @@
+package strex
+
+import (
+	"bytes"
+	"encoding/gob"
+	"iter"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/djhworld/strex/internal/runes"
+)
+
+// Trie maps strings to values of type V and answers prefix queries.
+//
+// It is stored in radix-compressed form: each edge is labelled with a string
+// of one or more runes rather than a single rune, so chains of nodes with one
+// child take up a single node. Keys are visited in lexicographic order. The
+// zero value is an empty Trie. A Trie can be serialized with encoding/gob
+// when V can.
+type Trie[V any] struct {
+	root trieNode[V]
+}
+
+type trieNode[V any] struct {
+	label    string // label of the edge leading to this node
+	children []*trieNode[V]
+	value    V
+	hasValue bool
+	size     int // number of keys in this subtree
+}
+
+// firstRune returns the bytes of the first rune of s. Invalid UTF-8 counts
+// as a rune of one byte, so different invalid bytes stay distinct from each
+// other and from U+FFFD.
+func firstRune(s string) string {
+	_, size := utf8.DecodeRuneInString(s)
+	return s[:size]
+}
+
+// child returns the index of the child whose label starts with the first
+// rune of s, or the index at which such a child would be inserted
+func (n *trieNode[V]) child(s string) (int, bool) {
+	r := firstRune(s)
+	i := sort.Search(len(n.children), func(i int) bool {
+		return firstRune(n.children[i].label) >= r
+	})
+	if i < len(n.children) {
+		return i, firstRune(n.children[i].label) == r
+	}
+	return i, false
+}
+
+// find returns the node for key, or nil
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	n := &t.root
+	for key != "" {
+		i, ok := n.child(key)
+		if !ok || !strings.HasPrefix(key, n.children[i].label) {
+			return nil
+		}
+		n = n.children[i]
+		key = key[len(n.label):]
+	}
+	return n
+}
+
+// Len returns the number of keys in t
+func (t *Trie[V]) Len() int {
+	return t.root.size
+}
+
+// Get returns the value stored for key, and whether there was one
+func (t *Trie[V]) Get(key string) (V, bool) {
+	var zero V
+	if n := t.find(key); n != nil && n.hasValue {
+		return n.value, true
+	}
+	return zero, false
+}
+
+// Insert stores v for key, replacing any previous value
+func (t *Trie[V]) Insert(key string, v V) {
+	if n := t.find(key); n != nil && n.hasValue {
+		n.value = v
+		return
+	}
+	n := &t.root
+	n.size++
+	for key != "" {
+		i, ok := n.child(key)
+		l := 0
+		if ok {
+			l, _ = runes.PrefixLen(runes.Equal, n.children[i].label, key)
+		}
+		if l == 0 {
+			c := &trieNode[V]{label: key, value: v, hasValue: true, size: 1}
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = c
+			return
+		}
+		c := n.children[i]
+		if l < len(c.label) {
+			mid := &trieNode[V]{label: c.label[:l], children: []*trieNode[V]{c}, size: c.size}
+			c.label = c.label[l:]
+			n.children[i] = mid
+			c = mid
+		}
+		c.size++
+		n = c
+		key = key[l:]
+	}
+	n.value, n.hasValue = v, true
+}
+
+// Delete removes key from t and reports whether it was present
+func (t *Trie[V]) Delete(key string) bool {
+	if n := t.find(key); n == nil || !n.hasValue {
+		return false
+	}
+	var path []*trieNode[V]
+	n := &t.root
+	for key != "" {
+		n.size--
+		path = append(path, n)
+		i, _ := n.child(key)
+		n = n.children[i]
+		key = key[len(n.label):]
+	}
+	var zero V
+	n.size--
+	n.value, n.hasValue = zero, false
+
+	// remove the node if it is now empty and merge its parent into the
+	// remaining child if that leaves the parent with nothing but one child
+	for k := len(path) - 1; k >= 0 && n.size == 0; k-- {
+		p := path[k]
+		i, _ := p.child(n.label)
+		p.children = append(p.children[:i], p.children[i+1:]...)
+		n = p
+	}
+	if n != &t.root && !n.hasValue && len(n.children) == 1 {
+		c := n.children[0]
+		n.label += c.label
+		n.children, n.value, n.hasValue = c.children, c.value, c.hasValue
+	}
+	return true
+}
+
+// walk yields every key in the subtree of n in lexicographic order, where
+// prefix is the key of n
+func (n *trieNode[V]) walk(prefix string, yield func(string, V) bool) bool {
+	if n.hasValue && !yield(prefix, n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(prefix+c.label, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over all keys and values of t in lexicographic
+// order of the keys
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return t.WithPrefix("")
+}
+
+// WithPrefix returns an iterator over the keys of t that start with p, and
+// their values, in lexicographic order of the keys
+func (t *Trie[V]) WithPrefix(p string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n, key, rest := &t.root, "", p
+		for rest != "" {
+			i, ok := n.child(rest)
+			if !ok {
+				return
+			}
+			c := n.children[i]
+			switch {
+			case strings.HasPrefix(rest, c.label):
+				rest = rest[len(c.label):]
+			case strings.HasPrefix(c.label, rest):
+				rest = ""
+			default:
+				return
+			}
+			n, key = c, key+c.label
+		}
+		n.walk(key, yield)
+	}
+}
+
+// LongestPrefixOf returns the longest key in t that is a prefix of s, along
+// with its value. The last result is false if no key is a prefix of s.
+func (t *Trie[V]) LongestPrefixOf(s string) (string, V, bool) {
+	var best *trieNode[V]
+	bestLen := 0
+	n, rest := &t.root, s
+	for {
+		if n.hasValue {
+			best, bestLen = n, len(s)-len(rest)
+		}
+		if rest == "" {
+			break
+		}
+		i, ok := n.child(rest)
+		if !ok || !strings.HasPrefix(rest, n.children[i].label) {
+			break
+		}
+		n = n.children[i]
+		rest = rest[len(n.label):]
+	}
+	if best == nil {
+		var zero V
+		return "", zero, false
+	}
+	return s[:bestLen], best.value, true
+}
+
+// ShortestUniquePrefix returns the shortest prefix of key that no other key
+// in t starts with, which is what a command line needs to type to select
+// key unambiguously. The result is false if key is not in t or is itself a
+// prefix of another key.
+func (t *Trie[V]) ShortestUniquePrefix(key string) (string, bool) {
+	if n := t.find(key); n == nil || !n.hasValue {
+		return "", false
+	}
+	if t.root.size == 1 {
+		return "", true
+	}
+	n, rest := &t.root, key
+	for rest != "" {
+		i, _ := n.child(rest)
+		c := n.children[i]
+		if c.size == 1 {
+			_, sz := utf8.DecodeRuneInString(rest)
+			return key[:len(key)-len(rest)+sz], true
+		}
+		n = c
+		rest = rest[len(c.label):]
+	}
+	return "", false
+}
+
+// trieGob is the serialized form of a Trie
+type trieGob[V any] struct {
+	Keys   []string
+	Values []V
+}
+
+// GobEncode implements gob.GobEncoder
+func (t *Trie[V]) GobEncode() ([]byte, error) {
+	var g trieGob[V]
+	for k, v := range t.All() {
+		g.Keys = append(g.Keys, k)
+		g.Values = append(g.Values, v)
+	}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(g); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder
+func (t *Trie[V]) GobDecode(data []byte) error {
+	var g trieGob[V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+	*t = Trie[V]{}
+	for i, k := range g.Keys {
+		t.Insert(k, g.Values[i])
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package strex
+
+import (
+	"bytes"
+	"encoding/gob"
+	"github.com/bmizerany/assert"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func collect[V any](seq func(func(string, V) bool)) []string {
+	keys := []string{}
+	seq(func(k string, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// checkCompact verifies that no node below the root could be merged with its
+// only child
+func checkCompact[V any](t *testing.T, n *trieNode[V], root bool) {
+	if !root {
+		assert.T(t, n.hasValue || len(n.children) > 1, "uncompressed node", n.label)
+	}
+	for _, c := range n.children {
+		checkCompact(t, c, false)
+	}
+}
+
+// --------------------- TRIE ------------------------
+func TestTrie(t *testing.T) {
+	var trie Trie[int]
+	for i, k := range []string{"help", "hello", "history", "quit", "héllo"} {
+		trie.Insert(k, i)
+	}
+	assert.Equal(t, trie.Len(), 5)
+
+	v, ok := trie.Get("hello")
+	assert.Equal(t, v, 1)
+	assert.Equal(t, ok, true)
+	_, ok = trie.Get("hel")
+	assert.Equal(t, ok, false)
+
+	assert.Equal(t, collect(trie.WithPrefix("he")), []string{"hello", "help"})
+	assert.Equal(t, collect(trie.WithPrefix("h")), []string{"hello", "help", "history", "héllo"})
+	assert.Equal(t, collect(trie.WithPrefix("x")), []string{})
+	assert.Equal(t, collect(trie.All()), []string{"hello", "help", "history", "héllo", "quit"})
+}
+
+func TestTrieLongestPrefixOf(t *testing.T) {
+	var trie Trie[string]
+	trie.Insert("/", "root")
+	trie.Insert("/usr", "usr")
+	trie.Insert("/usr/lib", "lib")
+
+	k, v, ok := trie.LongestPrefixOf("/usr/local/bin")
+	assert.Equal(t, k, "/usr")
+	assert.Equal(t, v, "usr")
+	assert.Equal(t, ok, true)
+
+	_, _, ok = trie.LongestPrefixOf("etc")
+	assert.Equal(t, ok, false)
+}
+
+func TestTrieShortestUniquePrefix(t *testing.T) {
+	var trie Trie[bool]
+	for _, k := range []string{"help", "hello", "history", "quit", "éxit", "q"} {
+		trie.Insert(k, true)
+	}
+	var p string
+	var ok bool
+	p, ok = trie.ShortestUniquePrefix("history")
+	assert.Equal(t, p, "hi")
+	assert.Equal(t, ok, true)
+	p, ok = trie.ShortestUniquePrefix("help")
+	assert.Equal(t, p, "help")
+	p, ok = trie.ShortestUniquePrefix("éxit")
+	assert.Equal(t, p, "é")
+	p, ok = trie.ShortestUniquePrefix("quit")
+	assert.Equal(t, p, "qu")
+	_, ok = trie.ShortestUniquePrefix("q")
+	assert.Equal(t, ok, false)
+	_, ok = trie.ShortestUniquePrefix("missing")
+	assert.Equal(t, ok, false)
+}
+
+func TestTrieDelete(t *testing.T) {
+	var trie Trie[int]
+	trie.Insert("team", 1)
+	trie.Insert("tea", 2)
+	trie.Insert("ten", 3)
+	assert.Equal(t, trie.Delete("te"), false)
+	assert.Equal(t, trie.Delete("tea"), true)
+	assert.Equal(t, trie.Len(), 2)
+	assert.Equal(t, collect(trie.All()), []string{"team", "ten"})
+	assert.Equal(t, trie.Delete("team"), true)
+	assert.Equal(t, trie.Delete("ten"), true)
+	assert.Equal(t, trie.Len(), 0)
+	assert.Equal(t, len(trie.root.children), 0)
+}
+
+func TestTrieInvalidUTF8(t *testing.T) {
+	var trie Trie[int]
+	keys := []string{"\xff", "\xfe", "\uFFFD", "\xffa", "\uFFFDb", "a\xff", "a\xfe"}
+	for i, k := range keys {
+		trie.Insert(k, i)
+	}
+	assert.Equal(t, trie.Len(), len(keys))
+	for i, k := range keys {
+		v, ok := trie.Get(k)
+		assert.T(t, ok, k)
+		assert.Equal(t, v, i)
+	}
+	assert.Equal(t, collect(trie.All()), []string{"a\xfe", "a\xff", "\uFFFD", "\uFFFDb", "\xfe", "\xff", "\xffa"})
+	checkCompact(t, &trie.root, true)
+	assert.Equal(t, trie.Delete("\xfe"), true)
+	_, ok := trie.Get("\xfe")
+	assert.Equal(t, ok, false)
+	assert.Equal(t, trie.Len(), len(keys)-1)
+}
+
+func TestTrieRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var alphabet []rune = []rune("abé")
+	random := func() string {
+		rs := make([]rune, rnd.Intn(5))
+		for i := range rs {
+			rs[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(rs)
+	}
+	var trie Trie[int]
+	expected := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		k := random()
+		if rnd.Intn(3) == 0 {
+			_, had := expected[k]
+			assert.Equal(t, trie.Delete(k), had)
+			delete(expected, k)
+		} else {
+			trie.Insert(k, i)
+			expected[k] = i
+		}
+		assert.Equal(t, trie.Len(), len(expected))
+		checkCompact(t, &trie.root, true)
+	}
+	var keys []string = slices.Sorted(maps.Keys(expected))
+	assert.Equal(t, collect(trie.All()), keys)
+	for _, p := range []string{"a", "é", "ab", "bé"} {
+		var want []string = []string{}
+		for _, k := range keys {
+			if strings.HasPrefix(k, p) {
+				want = append(want, k)
+			}
+		}
+		assert.Equal(t, collect(trie.WithPrefix(p)), want)
+	}
+}
+
+func TestTrieGob(t *testing.T) {
+	var trie Trie[int]
+	trie.Insert("alpha", 1)
+	trie.Insert("alps", 2)
+	trie.Insert("", 3)
+
+	var b bytes.Buffer
+	assert.Equal(t, gob.NewEncoder(&b).Encode(&trie), nil)
+	var decoded Trie[int]
+	assert.Equal(t, gob.NewDecoder(&b).Decode(&decoded), nil)
+	assert.Equal(t, collect(decoded.All()), []string{"", "alpha", "alps"})
+	v, _ := decoded.Get("alps")
+	assert.Equal(t, v, 2)
+}
@@ -0,0 +1,253 @@
+/*
+Package utf16x provides counterparts of the strex functions for UTF-16 text
+held as []uint16, as produced by Windows APIs and Java serializations.
+
+A surrogate pair counts as a single rune and is never split. An unpaired
+surrogate counts as one rune that decodes as unicode.ReplacementChar, the
+way strex treats a byte of invalid UTF-8. Functions that return part of
+their argument return a subslice of it and keep unpaired surrogates as they
+are; functions that build new text encode them as U+FFFD.
+
+ToString and FromString convert to and from Go strings without losing
+unpaired surrogates, which they carry in the WTF-8 encoding.
+*/
+package utf16x
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	surr1    = 0xd800 // first high surrogate
+	surr2    = 0xdc00 // first low surrogate
+	surr3    = 0xe000 // first code unit after the surrogates
+	replChar = unicode.ReplacementChar
+)
+
+// decode returns the first rune of s and the number of code units it takes
+func decode(s []uint16) (rune, int) {
+	c := rune(s[0])
+	switch {
+	case c < surr1 || surr3 <= c:
+		return c, 1
+	case c < surr2 && len(s) > 1 && surr2 <= s[1] && s[1] < surr3:
+		return utf16.DecodeRune(c, rune(s[1])), 2
+	}
+	return replChar, 1
+}
+
+// decodeLast returns the last rune of s and the number of code units it takes
+func decodeLast(s []uint16) (rune, int) {
+	n := len(s)
+	c := rune(s[n-1])
+	switch {
+	case c < surr1 || surr3 <= c:
+		return c, 1
+	case surr2 <= c && n > 1 && surr1 <= s[n-2] && s[n-2] < surr2:
+		return utf16.DecodeRune(rune(s[n-2]), c), 2
+	}
+	return replChar, 1
+}
+
+// runeOffset returns the index of the code unit that starts the n-th rune
+// of s, or -1 if s has fewer than n runes
+func runeOffset(n int, s []uint16) int {
+	i := 0
+	for ; n > 0 && i < len(s); n-- {
+		_, sz := decode(s[i:])
+		i += sz
+	}
+	if n > 0 {
+		return -1
+	}
+	return i
+}
+
+// Head returns the first rune of s, which must be non-empty
+func Head(s []uint16) rune {
+	if len(s) == 0 {
+		panic("empty list")
+	}
+	r, _ := decode(s)
+	return r
+}
+
+// Tail returns s without its first rune. s must be non-empty.
+func Tail(s []uint16) []uint16 {
+	if len(s) == 0 {
+		panic("empty list")
+	}
+	_, sz := decode(s)
+	return s[sz:]
+}
+
+// Take returns the first n runes of s, or s itself if it is shorter
+func Take(n int, s []uint16) []uint16 {
+	if n <= 0 {
+		return s[:0]
+	}
+	if i := runeOffset(n, s); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// Drop returns s without its first n runes, or an empty slice if it is
+// shorter
+func Drop(n int, s []uint16) []uint16 {
+	if n <= 0 {
+		return s
+	}
+	if i := runeOffset(n, s); i >= 0 {
+		return s[i:]
+	}
+	return s[len(s):]
+}
+
+// Len returns the number of runes in s
+func Len(s []uint16) int {
+	n := 0
+	for i := 0; i < len(s); n++ {
+		_, sz := decode(s[i:])
+		i += sz
+	}
+	return n
+}
+
+// Reverse returns the runes of s in reverse order
+func Reverse(s []uint16) []uint16 {
+	t := make([]uint16, 0, len(s))
+	for len(s) > 0 {
+		r, sz := decodeLast(s)
+		t = utf16.AppendRune(t, r)
+		s = s[:len(s)-sz]
+	}
+	return t
+}
+
+// Filter returns the runes of s that satisfy p
+func Filter(p func(rune) bool, s []uint16) []uint16 {
+	t := make([]uint16, 0, len(s))
+	for i := 0; i < len(s); {
+		r, sz := decode(s[i:])
+		if p(r) {
+			t = utf16.AppendRune(t, r)
+		}
+		i += sz
+	}
+	return t
+}
+
+// Group splits s into runs of equal runes. The runs are subslices of s.
+func Group(s []uint16) [][]uint16 {
+	return GroupBy(func(a, b rune) bool { return a == b }, s)
+}
+
+// GroupBy splits s into runs in which p holds for the first rune and every
+// other rune of the run. The runs are subslices of s.
+func GroupBy(p func(rune, rune) bool, s []uint16) [][]uint16 {
+	groups := [][]uint16{}
+	for len(s) > 0 {
+		r0, n := decode(s)
+		for n < len(s) {
+			r, sz := decode(s[n:])
+			if !p(r0, r) {
+				break
+			}
+			n += sz
+		}
+		groups = append(groups, s[:n])
+		s = s[n:]
+	}
+	return groups
+}
+
+// Distinct returns the first occurrence of each rune of s
+func Distinct(s []uint16) []uint16 {
+	var ascii [128]bool
+	var other map[rune]bool
+	return Filter(func(r rune) bool {
+		if r < 128 {
+			seen := ascii[r]
+			ascii[r] = true
+			return !seen
+		}
+		if other == nil {
+			other = make(map[rune]bool)
+		}
+		seen := other[r]
+		other[r] = true
+		return !seen
+	}, s)
+}
+
+// SurrogateError lists the unpaired surrogates met by ToString or
+// FromString. The conversion is still complete when it is returned.
+type SurrogateError struct {
+	Offsets []int // code unit indexes for ToString, byte offsets for FromString
+}
+
+func (e *SurrogateError) Error() string {
+	msg := "utf16x: unpaired surrogate at offset " + strconv.Itoa(e.Offsets[0])
+	if n := len(e.Offsets) - 1; n > 0 {
+		msg += " and " + strconv.Itoa(n) + " more"
+	}
+	return msg
+}
+
+// ToString converts s to a Go string. Unpaired surrogates are written in
+// WTF-8, as the three byte UTF-8 encoding of the surrogate, so that
+// FromString gives back s exactly, and their indexes are reported in a
+// *SurrogateError.
+func ToString(s []uint16) (string, error) {
+	b := make([]byte, 0, len(s))
+	var bad []int
+	for i := 0; i < len(s); {
+		r, sz := decode(s[i:])
+		if r == replChar && surr1 <= s[i] && s[i] < surr3 {
+			c := s[i]
+			b = append(b, 0xe0|byte(c>>12), 0x80|byte(c>>6)&0x3f, 0x80|byte(c)&0x3f)
+			bad = append(bad, i)
+		} else {
+			b = utf8.AppendRune(b, r)
+		}
+		i += sz
+	}
+	if bad != nil {
+		return string(b), &SurrogateError{Offsets: bad}
+	}
+	return string(b), nil
+}
+
+// FromString converts s to UTF-16. Surrogates written in WTF-8 by ToString
+// become surrogate code units again, and their byte offsets are reported in
+// a *SurrogateError. Other invalid UTF-8 becomes U+FFFD, as it does when
+// ranging over a string.
+func FromString(s string) ([]uint16, error) {
+	t := make([]uint16, 0, len(s))
+	var bad []int
+	for i := 0; i < len(s); {
+		if isSurrogate(s[i:]) {
+			t = append(t, 0xd000|uint16(s[i+1]&0x3f)<<6|uint16(s[i+2]&0x3f))
+			bad = append(bad, i)
+			i += 3
+			continue
+		}
+		r, sz := utf8.DecodeRuneInString(s[i:])
+		t = utf16.AppendRune(t, r)
+		i += sz
+	}
+	if bad != nil {
+		return t, &SurrogateError{Offsets: bad}
+	}
+	return t, nil
+}
+
+// isSurrogate reports whether s starts with the WTF-8 encoding of a
+// surrogate, which UTF-8 proper does not allow
+func isSurrogate(s string) bool {
+	return len(s) >= 3 && s[0] == 0xed && 0xa0 <= s[1] && s[1] <= 0xbf && 0x80 <= s[2] && s[2] <= 0xbf
+}
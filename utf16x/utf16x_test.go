@@ -0,0 +1,103 @@
+package utf16x
+
+import (
+	"github.com/bmizerany/assert"
+	"github.com/djhworld/strex"
+	"math/rand"
+	"testing"
+	"unicode"
+	"unicode/utf16"
+)
+
+var inputs []string = []string{"", "a", "hello", "héllo wörld", "日本語のテキスト", "aa\U0001F600\U0001F600b\U0001F601", "\U0001D11E\U0001D11E\U0001D11F"}
+
+func str(s []uint16) string {
+	return string(utf16.Decode(s))
+}
+
+// --------------------- STREX ------------------------
+func TestMatchesStrex(t *testing.T) {
+	for _, s := range inputs {
+		u := utf16.Encode([]rune(s))
+		for n := -1; n <= len(s)+1; n++ {
+			assert.Equal(t, str(Take(n, u)), strex.Take(n, s))
+			assert.Equal(t, str(Drop(n, u)), strex.Drop(n, s))
+		}
+		assert.Equal(t, Len(u), strex.Len(s))
+		assert.Equal(t, str(Reverse(u)), strex.Reverse(s))
+		assert.Equal(t, str(Filter(unicode.IsLetter, u)), strex.Filter(unicode.IsLetter, s))
+		assert.Equal(t, str(Distinct(u)), strex.Distinct(s))
+		groups := []string{}
+		for _, g := range Group(u) {
+			groups = append(groups, str(g))
+		}
+		assert.Equal(t, groups, strex.Group(s))
+		if s != "" {
+			assert.Equal(t, Head(u), strex.Head(s))
+			assert.Equal(t, str(Tail(u)), strex.Tail(s))
+		}
+	}
+}
+
+// --------------------- SURROGATES ------------------------
+func TestSurrogatePairsAreNotSplit(t *testing.T) {
+	u := utf16.Encode([]rune("a\U0001F600b"))
+	assert.Equal(t, len(u), 4)
+	assert.Equal(t, Take(2, u), u[:3])
+	assert.Equal(t, Drop(1, u), u[1:])
+	assert.Equal(t, Head(Drop(1, u)), '\U0001F600')
+	assert.Equal(t, Reverse(u), utf16.Encode([]rune("b\U0001F600a")))
+}
+
+func TestUnpairedSurrogates(t *testing.T) {
+	u := []uint16{'a', 0xdc00, 0xd800, 'b', 0xd800}
+	assert.Equal(t, Len(u), 5)
+	assert.Equal(t, Head(u[1:]), unicode.ReplacementChar)
+	assert.Equal(t, Take(3, u), u[:3])
+	assert.Equal(t, Drop(4, u), u[4:])
+	// built text does not turn the unpaired surrogates into a pair
+	assert.Equal(t, Reverse(u), []uint16{0xfffd, 'b', 0xfffd, 0xfffd, 'a'})
+	assert.Equal(t, Distinct(u), []uint16{'a', 0xfffd, 'b'})
+	assert.Equal(t, len(Group(u)), 4)
+}
+
+// --------------------- CONVERSION ------------------------
+func TestToString(t *testing.T) {
+	for _, s := range inputs {
+		got, err := ToString(utf16.Encode([]rune(s)))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, got, s)
+	}
+
+	got, err := ToString([]uint16{'a', 0xd83d, 'b', 0xdc00})
+	assert.Equal(t, got, "a\xed\xa0\xbdb\xed\xb0\x80")
+	assert.Equal(t, err.(*SurrogateError).Offsets, []int{1, 3})
+	assert.Equal(t, err.Error(), "utf16x: unpaired surrogate at offset 1 and 1 more")
+}
+
+func TestFromString(t *testing.T) {
+	for _, s := range inputs {
+		got, err := FromString(s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, got, utf16.Encode([]rune(s)))
+	}
+
+	got, err := FromString("a\xed\xa0\xbdb\xff")
+	assert.Equal(t, got, []uint16{'a', 0xd83d, 'b', 0xfffd})
+	assert.Equal(t, err.(*SurrogateError).Offsets, []int{1})
+	assert.Equal(t, err.Error(), "utf16x: unpaired surrogate at offset 1")
+}
+
+func TestRoundTripIsLossless(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	units := []uint16{'a', 0xe9, 0x65e5, 0xd83d, 0xde00, 0xdc00, 0xfffd}
+	for i := 0; i < 500; i++ {
+		u := make([]uint16, rnd.Intn(12))
+		for j := range u {
+			u[j] = units[rnd.Intn(len(units))]
+		}
+		s, _ := ToString(u)
+		back, _ := FromString(s)
+		assert.Equal(t, back, u)
+	}
+}
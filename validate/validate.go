@@ -0,0 +1,231 @@
+/*
+Package validate checks strings against rules that, unlike strex.All, say why
+a string was rejected.
+
+A failing rule returns an *Error naming the rule and giving the rune offset
+of the first offending rune, so that a form can point at the character to
+fix. Rules are combined with And and Or and can be given friendlier names
+with Named:
+
+	username := validate.And(
+		validate.LenRunes(3, 20),
+		validate.MatchesClass(`[a-z0-9_]`),
+		validate.NoRunsLongerThan(2).Named("no tripled characters"),
+	)
+	if err := username.Check(s); err != nil {
+		...
+	}
+*/
+package validate
+
+import (
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/djhworld/strex"
+)
+
+// Error is returned by Rule.Check when a string breaks a rule
+type Error struct {
+	Rule   string // name of the rule that failed
+	Offset int    // rune offset of the first offending rune, or the rune length of the string when it is missing something
+}
+
+func (e *Error) Error() string {
+	return "validate: " + e.Rule + " failed at rune " + strconv.Itoa(e.Offset)
+}
+
+// Rule is a named check of a string. The zero Rule accepts everything.
+type Rule struct {
+	name  string
+	check func(s string) *Error
+}
+
+// newRule returns a rule whose failures are reported under name, at the
+// offset returned by check, which returns -1 when s is acceptable
+func newRule(name string, check func(s string) int) Rule {
+	return Rule{name, func(s string) *Error {
+		if off := check(s); off >= 0 {
+			return &Error{Rule: name, Offset: off}
+		}
+		return nil
+	}}
+}
+
+// Name returns the name under which failures of r are reported
+func (r Rule) Name() string {
+	return r.name
+}
+
+// Named returns r with its failures reported under name
+func (r Rule) Named(name string) Rule {
+	return Rule{name, func(s string) *Error {
+		if err := r.run(s); err != nil {
+			return &Error{Rule: name, Offset: err.Offset}
+		}
+		return nil
+	}}
+}
+
+// Check returns nil if s satisfies r and an *Error otherwise
+func (r Rule) Check(s string) error {
+	if err := r.run(s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r Rule) run(s string) *Error {
+	if r.check == nil {
+		return nil
+	}
+	return r.check(s)
+}
+
+// AllOf requires every rune to satisfy p. The offending rune is the first
+// one that does not.
+func AllOf(p func(rune) bool) Rule {
+	return newRule("AllOf", func(s string) int {
+		i := 0
+		for _, r := range s {
+			if !p(r) {
+				return i
+			}
+			i++
+		}
+		return -1
+	})
+}
+
+// LenRunes requires s to have at least min and at most max runes. The
+// offending rune of a string that is too long is the one at offset max.
+func LenRunes(min, max int) Rule {
+	name := "LenRunes(" + strconv.Itoa(min) + ", " + strconv.Itoa(max) + ")"
+	return newRule(name, func(s string) int {
+		switch n := strex.Len(s); {
+		case n < min:
+			return n
+		case n > max:
+			return max
+		}
+		return -1
+	})
+}
+
+// NoRunsLongerThan forbids more than n equal runes in a row. The offending
+// rune is the first one that makes a run too long.
+func NoRunsLongerThan(n int) Rule {
+	return newRule("NoRunsLongerThan("+strconv.Itoa(n)+")", func(s string) int {
+		i := 0
+		for _, g := range strex.Group(s) {
+			k := utf8.RuneCountInString(g)
+			if k > n {
+				return i + n
+			}
+			i += k
+		}
+		return -1
+	})
+}
+
+// DistinctAtLeast requires s to contain at least k different runes
+func DistinctAtLeast(k int) Rule {
+	return newRule("DistinctAtLeast("+strconv.Itoa(k)+")", func(s string) int {
+		if strex.Len(strex.Distinct(s)) < k {
+			return strex.Len(s)
+		}
+		return -1
+	})
+}
+
+// MatchesClass requires every rune to belong to class, which is written as
+// a single character class of a regular expression, such as `[a-z0-9_]`,
+// `\d`, `\p{Greek}` or `[^[:space:]]`. It panics if class is not a valid
+// character class.
+func MatchesClass(class string) Rule {
+	re, err := syntax.Parse(class, syntax.Perl)
+	if err != nil {
+		panic("validate: MatchesClass(" + strconv.Quote(class) + "): " + err.Error())
+	}
+	var in func(rune) bool
+	switch re.Op {
+	case syntax.OpCharClass:
+		ranges := re.Rune
+		in = func(r rune) bool {
+			for i := 0; i < len(ranges); i += 2 {
+				if ranges[i] <= r && r <= ranges[i+1] {
+					return true
+				}
+			}
+			return false
+		}
+	case syntax.OpLiteral:
+		if len(re.Rune) != 1 {
+			panic("validate: MatchesClass(" + strconv.Quote(class) + "): not a character class")
+		}
+		c := re.Rune[0]
+		in = func(r rune) bool { return r == c }
+		if re.Flags&syntax.FoldCase != 0 {
+			// (?i) leaves a single letter as a literal; accept every
+			// rune in its case-folding orbit, as regexp does
+			in = func(r rune) bool {
+				for f := unicode.SimpleFold(c); f != c; f = unicode.SimpleFold(f) {
+					if r == f {
+						return true
+					}
+				}
+				return r == c
+			}
+		}
+	case syntax.OpAnyChar:
+		in = func(rune) bool { return true }
+	case syntax.OpAnyCharNotNL:
+		in = func(r rune) bool { return r != '\n' }
+	default:
+		panic("validate: MatchesClass(" + strconv.Quote(class) + "): not a character class")
+	}
+	return AllOf(in).Named("MatchesClass(" + class + ")")
+}
+
+// And requires s to satisfy every rule. Its failure is that of the first
+// rule that s breaks.
+func And(rules ...Rule) Rule {
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.name
+	}
+	return Rule{"(" + strings.Join(names, " and ") + ")", func(s string) *Error {
+		for _, r := range rules {
+			if err := r.run(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
+
+// Or requires s to satisfy at least one of the rules. Its failure names all
+// of them and gives the largest offset reached by any of them, which is how
+// far s got before no rule would accept it. Or with no rules accepts
+// nothing.
+func Or(rules ...Rule) Rule {
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.name
+	}
+	name := "(" + strings.Join(names, " or ") + ")"
+	return Rule{name, func(s string) *Error {
+		off := 0
+		for _, r := range rules {
+			err := r.run(s)
+			if err == nil {
+				return nil
+			}
+			off = max(off, err.Offset)
+		}
+		return &Error{Rule: name, Offset: off}
+	}}
+}
@@ -0,0 +1,129 @@
+package validate
+
+import (
+	"github.com/bmizerany/assert"
+	"testing"
+	"unicode"
+)
+
+// failure returns the rule and offset of the error of r for s, or "" and -1
+func failure(r Rule, s string) (string, int) {
+	err := r.Check(s)
+	if err == nil {
+		return "", -1
+	}
+	e := err.(*Error)
+	return e.Rule, e.Offset
+}
+
+func assertFails(t *testing.T, r Rule, s, rule string, offset int) {
+	gotRule, gotOffset := failure(r, s)
+	assert.Equal(t, gotRule, rule, s)
+	assert.Equal(t, gotOffset, offset, s)
+}
+
+func assertPasses(t *testing.T, r Rule, s string) {
+	assert.Equal(t, r.Check(s), nil, s)
+}
+
+// --------------------- RULES ------------------------
+func TestAllOf(t *testing.T) {
+	r := AllOf(unicode.IsLetter)
+	assertPasses(t, r, "")
+	assertPasses(t, r, "héllo")
+	assertFails(t, r, "héllo1", "AllOf", 5)
+	assertFails(t, r, "日本2語", "AllOf", 2)
+}
+
+func TestLenRunes(t *testing.T) {
+	r := LenRunes(2, 4)
+	assertPasses(t, r, "日本")
+	assertPasses(t, r, "abcd")
+	assertFails(t, r, "日", "LenRunes(2, 4)", 1)
+	assertFails(t, r, "", "LenRunes(2, 4)", 0)
+	assertFails(t, r, "日本語のテ", "LenRunes(2, 4)", 4)
+}
+
+func TestNoRunsLongerThan(t *testing.T) {
+	r := NoRunsLongerThan(2)
+	assertPasses(t, r, "")
+	assertPasses(t, r, "aabbaa")
+	assertFails(t, r, "ééxxx", "NoRunsLongerThan(2)", 4)
+	assertFails(t, r, "abbbb", "NoRunsLongerThan(2)", 3)
+}
+
+func TestDistinctAtLeast(t *testing.T) {
+	r := DistinctAtLeast(3)
+	assertPasses(t, r, "abc")
+	assertPasses(t, r, "aabbcc")
+	assertFails(t, r, "aabbab", "DistinctAtLeast(3)", 6)
+}
+
+func TestMatchesClass(t *testing.T) {
+	r := MatchesClass(`[a-z0-9_]`)
+	assertPasses(t, r, "user_01")
+	assertFails(t, r, "user-01", "MatchesClass([a-z0-9_])", 4)
+	assertFails(t, r, "üser", "MatchesClass([a-z0-9_])", 0)
+
+	assertPasses(t, MatchesClass(`\d`), "0123")
+	assertFails(t, MatchesClass(`\p{Greek}`), "αβγd", `MatchesClass(\p{Greek})`, 3)
+	assertFails(t, MatchesClass(`[^[:space:]]`), "ab c", "MatchesClass([^[:space:]])", 2)
+	assertFails(t, MatchesClass(`x`), "xxy", "MatchesClass(x)", 2)
+	assertFails(t, MatchesClass(`.`), "a\nb", "MatchesClass(.)", 1)
+
+	assertPasses(t, MatchesClass(`(?i)k`), "kK\u212A")
+	assertFails(t, MatchesClass(`(?i)k`), "kKx", "MatchesClass((?i)k)", 2)
+	assertPasses(t, MatchesClass(`(?i)[a-z]`), "HeLLo")
+}
+
+func TestMatchesClassPanics(t *testing.T) {
+	for _, class := range []string{`[a-`, `ab`, `a+`} {
+		func() {
+			defer func() {
+				assert.NotEqual(t, recover(), nil, class)
+			}()
+			MatchesClass(class)
+		}()
+	}
+}
+
+// --------------------- COMPOSITION ------------------------
+func TestAnd(t *testing.T) {
+	r := And(LenRunes(3, 8), MatchesClass(`[a-z]`), NoRunsLongerThan(2))
+	assert.Equal(t, r.Name(), "(LenRunes(3, 8) and MatchesClass([a-z]) and NoRunsLongerThan(2))")
+	assertPasses(t, r, "hello")
+	assertFails(t, r, "hi", "LenRunes(3, 8)", 2)
+	assertFails(t, r, "heLlo", "MatchesClass([a-z])", 2)
+	assertFails(t, r, "helllo", "NoRunsLongerThan(2)", 4)
+	assertPasses(t, And(), "anything")
+}
+
+func TestOr(t *testing.T) {
+	r := Or(AllOf(unicode.IsDigit).Named("digits"), LenRunes(0, 3))
+	assert.Equal(t, r.Name(), "(digits or LenRunes(0, 3))")
+	assertPasses(t, r, "12345")
+	assertPasses(t, r, "abc")
+	assertFails(t, r, "abcd", "(digits or LenRunes(0, 3))", 3)
+	assertFails(t, r, "123456x", "(digits or LenRunes(0, 3))", 6)
+	assertFails(t, Or(), "", "()", 0)
+}
+
+func TestNested(t *testing.T) {
+	r := And(Or(MatchesClass(`\d`), MatchesClass(`[a-f]`)), DistinctAtLeast(2))
+	assertPasses(t, r, "123")
+	assertPasses(t, r, "cafe")
+	assertFails(t, r, "12ab", "(MatchesClass(\\d) or MatchesClass([a-f]))", 2)
+	assertFails(t, r, "aaa", "DistinctAtLeast(2)", 3)
+}
+
+func TestNamed(t *testing.T) {
+	r := And(LenRunes(1, 3), AllOf(unicode.IsUpper)).Named("code")
+	assert.Equal(t, r.Name(), "code")
+	assertFails(t, r, "ABCD", "code", 3)
+	assert.Equal(t, r.Check("AbC").Error(), "validate: code failed at rune 1")
+}
+
+func TestZeroRule(t *testing.T) {
+	var r Rule
+	assertPasses(t, r, "anything")
+}
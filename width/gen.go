@@ -0,0 +1,142 @@
+//go:build ignore
+
+// This program generates tables.go from the Unicode East Asian Width data.
+// Run it with
+//
+//	go run gen.go [-url URL | -in FILE]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	url = flag.String("url", "https://www.unicode.org/Public/UCD/latest/ucd/EastAsianWidth.txt", "location of EastAsianWidth.txt")
+	in  = flag.String("in", "", "read EastAsianWidth.txt from this file instead of -url")
+	out = flag.String("out", "tables.go", "output file")
+)
+
+var versionRE = regexp.MustCompile(`EastAsianWidth-(\d+\.\d+\.\d+)\.txt`)
+
+type span struct{ lo, hi uint32 }
+
+func main() {
+	flag.Parse()
+
+	var r io.Reader
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	} else {
+		resp, err := http.Get(*url)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			log.Fatalf("fetching %s: %s", *url, resp.Status)
+		}
+		r = resp.Body
+	}
+
+	version := ""
+	var wide []span
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if version == "" {
+			if m := versionRE.FindStringSubmatch(line); m != nil {
+				version = m[1]
+			}
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 2 {
+			continue
+		}
+		prop := strings.TrimSpace(fields[1])
+		if prop != "W" && prop != "F" {
+			continue
+		}
+		lo, hi, err := parseRange(strings.TrimSpace(fields[0]))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if n := len(wide); n > 0 && wide[n-1].hi+1 == lo {
+			wide[n-1].hi = hi
+		} else {
+			wide = append(wide, span{lo, hi})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package width\n\n")
+	fmt.Fprintf(&b, "import \"unicode\"\n\n")
+	fmt.Fprintf(&b, "// UnicodeVersion is the Unicode edition from which the tables are derived.\n")
+	fmt.Fprintf(&b, "const UnicodeVersion = %q\n\n", version)
+	fmt.Fprintf(&b, "// wide holds the code points with East_Asian_Width W or F.\n")
+	fmt.Fprintf(&b, "var wide = &unicode.RangeTable{\n")
+	fmt.Fprintf(&b, "R16: []unicode.Range16{\n")
+	for _, s := range wide {
+		if s.hi <= 0xFFFF {
+			fmt.Fprintf(&b, "{0x%04x, 0x%04x, 1},\n", s.lo, s.hi)
+		}
+	}
+	fmt.Fprintf(&b, "},\n")
+	fmt.Fprintf(&b, "R32: []unicode.Range32{\n")
+	for _, s := range wide {
+		if s.hi > 0xFFFF {
+			if s.lo <= 0xFFFF {
+				log.Fatalf("range %04X..%04X crosses the BMP boundary", s.lo, s.hi)
+			}
+			fmt.Fprintf(&b, "{0x%x, 0x%x, 1},\n", s.lo, s.hi)
+		}
+	}
+	fmt.Fprintf(&b, "},\n")
+	fmt.Fprintf(&b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseRange(s string) (lo, hi uint32, err error) {
+	a, b, found := strings.Cut(s, "..")
+	l, err := strconv.ParseUint(a, 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return uint32(l), uint32(l), nil
+	}
+	h, err := strconv.ParseUint(b, 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint32(l), uint32(h), nil
+}
@@ -0,0 +1,115 @@
+/*
+Package width provides display-width aware counterparts of strex.Take and
+strex.Drop for laying out text in a terminal.
+
+The width of a rune is the number of terminal columns it occupies: East Asian
+wide and fullwidth runes take two columns, combining marks and other
+zero-width runes take none, and everything else takes one. None of the
+functions in this package ever split a string inside a rune.
+*/
+package width
+
+//go:generate go run gen.go
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneWidth returns the number of terminal columns occupied by r
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (0x7f <= r && r < 0xa0):
+		return 0
+	case r < 0x300:
+		return 1
+	case isZeroWidth(r):
+		return 0
+	case unicode.Is(wide, r):
+		return 2
+	}
+	return 1
+}
+
+func isZeroWidth(r rune) bool {
+	switch {
+	case r == 0x200b: //ZERO WIDTH SPACE
+		return true
+	case 0x1160 <= r && r <= 0x11ff: //Hangul Jamo medial vowels and final consonants
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
+}
+
+// Width returns the number of terminal columns occupied by s
+func Width(s string) int {
+	w := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			for _, r := range s[i:] {
+				w += RuneWidth(r)
+			}
+			return w
+		}
+		w += RuneWidth(rune(s[i]))
+	}
+	return w
+}
+
+// prefix returns the length in bytes and the width in columns of the longest
+// prefix of s that fits in cols columns. Zero-width runes directly following
+// the prefix are included so that combining marks stay with their base.
+func prefix(cols int, s string) (int, int) {
+	w := 0
+	for i, r := range s {
+		rw := RuneWidth(r)
+		if w+rw > cols {
+			return i, w
+		}
+		w += rw
+	}
+	return len(s), w
+}
+
+// TakeWidth returns the longest prefix of s that fits in cols columns. A wide
+// rune that would only half fit is left out, so the result may be one column
+// narrower than cols.
+func TakeWidth(cols int, s string) string {
+	n, _ := prefix(cols, s)
+	return s[:n]
+}
+
+// DropWidth returns the suffix of s remaining after TakeWidth(cols, s)
+func DropWidth(cols int, s string) string {
+	n, _ := prefix(cols, s)
+	return s[n:]
+}
+
+// TruncateWidth returns s unchanged if it fits in cols columns, otherwise it
+// returns the longest prefix of s that fits together with ellipsis, followed by
+// ellipsis. If ellipsis itself does not fit it is truncated instead.
+func TruncateWidth(cols int, s, ellipsis string) string {
+	n, w := prefix(cols, s)
+	if n == len(s) {
+		return s
+	}
+	ew := Width(ellipsis)
+	if ew > cols {
+		return TakeWidth(cols, ellipsis)
+	}
+	if w+ew > cols {
+		n, _ = prefix(cols-ew, s)
+	}
+	return s[:n] + ellipsis
+}
+
+// PadRightWidth returns s followed by enough spaces to make it cols columns
+// wide. If s is already at least cols columns wide it is returned unchanged.
+func PadRightWidth(cols int, s string) string {
+	w := Width(s)
+	if w >= cols {
+		return s
+	}
+	return s + strings.Repeat(" ", cols-w)
+}
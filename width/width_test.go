@@ -0,0 +1,78 @@
+package width
+
+import (
+	"github.com/bmizerany/assert"
+	"testing"
+)
+
+// --------------------- WIDTH ------------------------
+func TestWidth(t *testing.T) {
+	assert.Equal(t, Width(""), 0)
+	assert.Equal(t, Width("hello"), 5)
+	assert.Equal(t, Width("日本語"), 6)
+	assert.Equal(t, Width("ｆｕｌｌ"), 8)
+	assert.Equal(t, Width("é"), 1)
+	assert.Equal(t, Width("a\tb"), 2)
+	assert.Equal(t, Width("😀"), 2)
+}
+
+func TestRuneWidth(t *testing.T) {
+	assert.Equal(t, RuneWidth('a'), 1)
+	assert.Equal(t, RuneWidth('é'), 1)
+	assert.Equal(t, RuneWidth('中'), 2)
+	assert.Equal(t, RuneWidth('\u0301'), 0)
+	assert.Equal(t, RuneWidth('\u200b'), 0)
+	assert.Equal(t, RuneWidth(0), 0)
+}
+
+// --------------------- TAKEWIDTH ------------------------
+func TestTakeWidth(t *testing.T) {
+	var input string = "日本語"
+	var expected string = "日本"
+	var actual string = TakeWidth(4, input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestTakeWidthDoesNotSplitWideRune(t *testing.T) {
+	var input string = "日本語"
+	var expected string = "日"
+	var actual string = TakeWidth(3, input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestTakeWidthKeepsCombiningMarks(t *testing.T) {
+	var input string = "cafe\u0301s"
+	var expected string = "cafe\u0301"
+	var actual string = TakeWidth(4, input)
+	assert.Equal(t, actual, expected)
+}
+
+func TestTakeWidthWithEmpty(t *testing.T) {
+	assert.Equal(t, TakeWidth(10, ""), "")
+	assert.Equal(t, TakeWidth(0, "abc"), "")
+	assert.Equal(t, TakeWidth(-1, "abc"), "")
+}
+
+// --------------------- DROPWIDTH ------------------------
+func TestDropWidth(t *testing.T) {
+	var input string = "日本語"
+	assert.Equal(t, DropWidth(4, input), "語")
+	assert.Equal(t, DropWidth(3, input), "本語")
+	assert.Equal(t, DropWidth(10, input), "")
+}
+
+// --------------------- TRUNCATEWIDTH ------------------------
+func TestTruncateWidth(t *testing.T) {
+	assert.Equal(t, TruncateWidth(10, "hello", "…"), "hello")
+	assert.Equal(t, TruncateWidth(4, "hello", "…"), "hel…")
+	assert.Equal(t, TruncateWidth(5, "日本語です", "..."), "日...")
+	assert.Equal(t, TruncateWidth(6, "日本語です", "..."), "日...")
+	assert.Equal(t, TruncateWidth(2, "hello", "..."), "..")
+}
+
+// --------------------- PADRIGHTWIDTH ------------------------
+func TestPadRightWidth(t *testing.T) {
+	assert.Equal(t, PadRightWidth(6, "日本"), "日本  ")
+	assert.Equal(t, PadRightWidth(6, "ab"), "ab    ")
+	assert.Equal(t, PadRightWidth(2, "abc"), "abc")
+}
@@ -0,0 +1,177 @@
+package strex
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// WrapAlgorithm selects how Wrap chooses where to break lines
+type WrapAlgorithm int
+
+const (
+	// WrapGreedy puts as many words as fit on each line before moving on
+	WrapGreedy WrapAlgorithm = iota
+	// WrapMinRaggedness chooses the breaks that minimize the sum of the
+	// squares of the unused space at the end of every line but the last, in
+	// the style of Knuth and Plass, which gives a more even right edge
+	WrapMinRaggedness
+)
+
+// WrapOptions configures WrapWith and FillWith. The zero value wraps greedily
+// without indentation, measuring text in runes.
+type WrapOptions struct {
+	Algorithm WrapAlgorithm
+	Indent    string           // prefix of the first line of each paragraph
+	Hanging   string           // prefix of the other lines of each paragraph
+	Width     func(string) int // display width of a string, e.g. width.Width
+}
+
+var paragraphBreak = regexp.MustCompile(`\n[ \t\r]*\n\s*`)
+
+// Wrap breaks s into lines of at most width runes, breaking at white space.
+// Paragraphs separated by a blank line are wrapped separately and stay
+// separated by an empty line. Words longer than width are broken between
+// runes.
+func Wrap(s string, width int) []string {
+	return WrapWith(s, width, WrapOptions{})
+}
+
+// Fill is like Wrap but returns the lines joined by newlines
+func Fill(s string, width int) string {
+	return strings.Join(Wrap(s, width), "\n")
+}
+
+// FillWith is like WrapWith but returns the lines joined by newlines
+func FillWith(s string, width int, opts WrapOptions) string {
+	return strings.Join(WrapWith(s, width, opts), "\n")
+}
+
+// WrapWith is like Wrap but with configurable options. The width of a line
+// includes its indentation.
+func WrapWith(s string, width int, opts WrapOptions) []string {
+	w := opts.Width
+	if w == nil {
+		w = utf8.RuneCountInString
+	}
+	first := max(1, width-w(opts.Indent))
+	rest := max(1, width-w(opts.Hanging))
+
+	lines := []string{}
+	for _, para := range paragraphBreak.Split(strings.TrimSpace(s), -1) {
+		words := hardBreak(strings.Fields(para), min(first, rest), opts.Width)
+		if len(words) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		var breaks []int
+		if opts.Algorithm == WrapMinRaggedness {
+			breaks = breakMinRaggedness(words, first, rest, w)
+		} else {
+			breaks = breakGreedy(words, first, rest, w)
+		}
+		start := 0
+		for i, end := range breaks {
+			prefix := opts.Hanging
+			if i == 0 {
+				prefix = opts.Indent
+			}
+			lines = append(lines, prefix+strings.Join(words[start:end], " "))
+			start = end
+		}
+	}
+	return lines
+}
+
+// hardBreak splits the words wider than width into pieces that fit. A nil
+// w measures in runes.
+func hardBreak(words []string, width int, w func(string) int) []string {
+	out := make([]string, 0, len(words))
+	measure := w
+	if measure == nil {
+		measure = utf8.RuneCountInString
+	}
+	for _, word := range words {
+		for measure(word) > width {
+			n, cols := 0, 0
+			for n < len(word) {
+				_, size := utf8.DecodeRuneInString(word[n:])
+				rw := 1
+				if w != nil {
+					rw = w(word[n : n+size])
+				}
+				if n > 0 && cols+rw > width {
+					break
+				}
+				n += size
+				cols += rw
+			}
+			out = append(out, word[:n])
+			word = word[n:]
+		}
+		if word != "" {
+			out = append(out, word)
+		}
+	}
+	return out
+}
+
+// breakGreedy returns the index of the word after the end of each line
+func breakGreedy(words []string, first, rest int, w func(string) int) []int {
+	var breaks []int
+	limit, cols := first, -1
+	for i, word := range words {
+		ww := w(word)
+		if cols >= 0 && cols+1+ww > limit {
+			breaks = append(breaks, i)
+			limit, cols = rest, -1
+		}
+		cols += 1 + ww
+	}
+	return append(breaks, len(words))
+}
+
+// breakMinRaggedness returns the index of the word after the end of each
+// line, chosen by dynamic programming over all possible breaks
+func breakMinRaggedness(words []string, first, rest int, w func(string) int) []int {
+	n := len(words)
+	widths := make([]int, n)
+	for i, word := range words {
+		widths[i] = w(word)
+	}
+	// cost[i] is the least cost of setting words[i:] on continuation lines
+	// and next[i] is the end of the first of those lines
+	cost := make([]int, n+1)
+	next := make([]int, n+1)
+	best := func(i, limit int) (int, int) {
+		bc, bj := -1, i+1
+		cols := -1
+		for j := i; j < n; j++ {
+			cols += 1 + widths[j]
+			if cols > limit && j > i {
+				break
+			}
+			c := 0
+			if j+1 < n {
+				c = (limit-cols)*(limit-cols) + cost[j+1]
+			}
+			if bc < 0 || c < bc {
+				bc, bj = c, j+1
+			}
+		}
+		return bc, bj
+	}
+	for i := n - 1; i >= 0; i-- {
+		cost[i], next[i] = best(i, rest)
+	}
+	var breaks []int
+	_, end := best(0, first)
+	breaks = append(breaks, end)
+	for end < n {
+		end = next[end]
+		breaks = append(breaks, end)
+	}
+	return breaks
+}
@@ -0,0 +1,75 @@
+package strex
+
+import (
+	"github.com/bmizerany/assert"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// --------------------- WRAP ------------------------
+func TestWrap(t *testing.T) {
+	var input string = "the quick brown fox jumps over the lazy dog"
+	var expected []string = []string{"the quick", "brown fox", "jumps over", "the lazy", "dog"}
+	var actual []string = Wrap(input, 10)
+	assert.Equal(t, actual, expected)
+}
+
+func TestWrapWithEmpty(t *testing.T) {
+	assert.Equal(t, Wrap("", 10), []string{})
+	assert.Equal(t, Wrap("  \n ", 10), []string{})
+}
+
+func TestWrapParagraphs(t *testing.T) {
+	var input string = "first paragraph\nhere\n\n  second one"
+	var expected []string = []string{"first", "paragraph", "here", "", "second one"}
+	assert.Equal(t, Wrap(input, 10), expected)
+}
+
+func TestWrapLongWord(t *testing.T) {
+	var input string = "a supercalifragilistic word"
+	var expected []string = []string{"a", "supercal", "ifragili", "stic", "word"}
+	assert.Equal(t, Wrap(input, 8), expected)
+	assert.Equal(t, Wrap("日本語日本語", 4), []string{"日本語日", "本語"})
+	assert.Equal(t, Wrap("ab\xff\xfecd", 3), []string{"ab\xff", "\xfecd"})
+	//only the result grows; measuring the runes does not allocate
+	word := strings.Repeat("é", 100)
+	for _, w := range []func(string) int{nil, utf8.RuneCountInString} {
+		allocs := testing.AllocsPerRun(10, func() { hardBreak([]string{word}, 8, w) })
+		assert.T(t, allocs < 10, allocs)
+	}
+}
+
+func TestWrapHangingIndent(t *testing.T) {
+	var input string = "-v, --verbose  print every file name as it is processed"
+	var opts WrapOptions = WrapOptions{Indent: "  ", Hanging: "                 "}
+	var expected string = "" +
+		"  -v, --verbose print every\n" +
+		"                 file name as\n" +
+		"                 it is\n" +
+		"                 processed"
+	assert.Equal(t, FillWith(input, 30, opts), expected)
+}
+
+func TestWrapMinRaggedness(t *testing.T) {
+	var input string = "aaa bb cc ddddd"
+	assert.Equal(t, Wrap(input, 6), []string{"aaa bb", "cc", "ddddd"})
+	var opts WrapOptions = WrapOptions{Algorithm: WrapMinRaggedness}
+	assert.Equal(t, WrapWith(input, 6, opts), []string{"aaa", "bb cc", "ddddd"})
+}
+
+func TestWrapMinRaggednessFits(t *testing.T) {
+	var input string = strings.Repeat("lorem ipsum dolor sit amet, consectetur adipiscing elit ", 5)
+	var opts WrapOptions = WrapOptions{Algorithm: WrapMinRaggedness}
+	var lines []string = WrapWith(input, 20, opts)
+	for _, l := range lines {
+		assert.T(t, utf8.RuneCountInString(l) <= 20, l)
+	}
+	assert.Equal(t, strings.Join(lines, " "), strings.TrimSpace(input))
+}
+
+func TestWrapDisplayWidth(t *testing.T) {
+	var double func(string) int = func(s string) int { return 2 * utf8.RuneCountInString(s) }
+	var opts WrapOptions = WrapOptions{Width: double}
+	assert.Equal(t, WrapWith("日本 語です", 5, opts), []string{"日本", "語で", "す"})
+}